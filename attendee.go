@@ -0,0 +1,130 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Attendee contains the details of a booking for an event, as seen by its organiser.
+type Attendee struct {
+	BookingID   int        `json:"booking_id"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	TicketName  string     `json:"ticket_name"`
+	CheckedIn   bool       `json:"checked_in"`
+	CheckInTime *time.Time `json:"check_in_time"`
+	QRCodeURL   string     `json:"qr_code_url"`
+}
+
+type attendeePage struct {
+	apiError
+	Items []Attendee `json:"results"`
+	Next  *string    `json:"next"`
+}
+
+func (c *Client) attendeePage(ctx context.Context, eventID, page, pageSize int) (*attendeePage, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, errors.New("page and page size must be positive")
+	}
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+	resp := attendeePage{}
+	if err := c.get(ctx, c.url(attendeesPath, eventID)+"?"+query.Encode(), true, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting attendees")
+	}
+	return &resp, nil
+}
+
+// GetEventAttendees returns the given page (starting at 1) of the attendees of the event
+// with the given ID. A *ForbiddenError will be returned if the user is not its organiser.
+func (c *Client) GetEventAttendees(ctx context.Context, eventID int, page, pageSize int) ([]Attendee, error) {
+	resp, err := c.attendeePage(ctx, eventID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// IterateAttendees returns a *PageIterator over the attendees of the event with the given ID,
+// fetching pageSize (50 if not positive) at a time (see GetEventAttendees).
+func (c *Client) IterateAttendees(eventID, pageSize int) *PageIterator[Attendee] {
+	if pageSize <= 0 {
+		pageSize = historyPageSize
+	}
+	return newPageIterator(func(ctx context.Context, page int) ([]Attendee, bool, error) {
+		resp, err := c.attendeePage(ctx, eventID, page, pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Items, resp.Next != nil, nil
+	})
+}
+
+// CheckInAttendee checks in the booking with the given ID at the event's entrance.
+// A *ForbiddenError will be returned if the user is not the event's organiser.
+func (c *Client) CheckInAttendee(ctx context.Context, bookingID int) error {
+	if err := c.patch(ctx, c.url(checkInPath, bookingID), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error checking in attendee")
+	}
+	return nil
+}
+
+// CheckinStats contains the attendance of an event, as seen by its organiser.
+// CheckinRate is the fraction (from 0 to 1) of TotalExpected who have checked in.
+type CheckinStats struct {
+	apiError
+	TotalExpected int                 `json:"total_expected"`
+	CheckedIn     int                 `json:"checked_in"`
+	Remaining     int                 `json:"remaining"`
+	CheckinRate   float64             `json:"checkin_rate"`
+	LastCheckinAt *time.Time          `json:"last_checkin_at"`
+	TicketStats   []TicketCheckinStat `json:"ticket_stats"`
+}
+
+// TicketCheckinStat contains the attendance for a single ticket type.
+type TicketCheckinStat struct {
+	TicketID      int    `json:"ticket_id"`
+	Name          string `json:"name"`
+	TotalExpected int    `json:"total_expected"`
+	CheckedIn     int    `json:"checked_in"`
+}
+
+// GetEventCheckinStats returns the attendance of the event with the given ID.
+// A *ForbiddenError will be returned if the user is not the event's organiser.
+func (c *Client) GetEventCheckinStats(ctx context.Context, eventID int) (*CheckinStats, error) {
+	stats := CheckinStats{}
+	if err := c.get(ctx, c.url(checkInStatsPath, eventID), true, &stats); err != nil {
+		return nil, errors.Wrap(err, "error getting check-in stats")
+	}
+	return &stats, nil
+}
+
+// PollCheckinStats fetches the attendance of the event with the given ID every interval (5s if
+// not positive), sending the stats each time, such as for a live dashboard. Errors are sent
+// without stopping the poller. Both channels are closed once ctx is done; callers must receive
+// from both until then.
+func (c *Client) PollCheckinStats(ctx context.Context, eventID int, interval time.Duration) (<-chan CheckinStats, <-chan error) {
+	stats, errs := make(chan CheckinStats), make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(stats)
+		poll(ctx, interval, func() {
+			s, err := c.GetEventCheckinStats(ctx, eventID)
+			if err != nil {
+				sendError(ctx, errs, err)
+				return
+			}
+			select {
+			case stats <- *s:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return stats, errs
+}
@@ -0,0 +1,94 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestIterateAttendees(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/event/1/attendees" {
+			t.Errorf("expected %s; got %s\n", "/event/1/attendees", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"next": "/event/1/attendees?page=2", "results": [{"booking_id": 1, "checked_in": true, "check_in_time": "2020-01-01T22:00:00Z"}]}`)
+		default:
+			fmt.Fprint(w, `{"next": null, "results": [{"booking_id": 2}]}`)
+		}
+	})
+	c.AuthToken = "abc"
+	it, attendees := c.IterateAttendees(1, 1), []Attendee{}
+	for it.Next(context.Background()) {
+		attendees = append(attendees, it.Value()...)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(attendees) != 2 || !attendees[0].CheckedIn || attendees[0].CheckInTime == nil || attendees[1].BookingID != 2 {
+		t.Errorf("expected 2 attendees; got %+v\n", attendees)
+	}
+}
+
+func TestCheckInAttendee(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/booking/7/check-in" {
+			t.Errorf("expected %s; got %s %s\n", "PATCH /booking/7/check-in", r.Method, r.URL.Path)
+		}
+	})
+	c.AuthToken = "abc"
+	if err := c.CheckInAttendee(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	c = newTestClient(t, statusHandler(http.StatusForbidden, `{"detail": "Not the organiser."}`))
+	c.AuthToken = "abc"
+	forbiddenErr := new(ForbiddenError)
+	if err := c.CheckInAttendee(context.Background(), 7); !errors.As(err, &forbiddenErr) {
+		t.Errorf("expected *ForbiddenError; got %v\n", err)
+	}
+}
+
+func TestPollCheckinStats(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/event/1/checkin/stats" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		checkedIn := atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, `{"total_expected": 10, "checked_in": %d, "remaining": %d, "checkin_rate": %.1f,
+			"ticket_stats": [{"ticket_id": 1, "total_expected": 10, "checked_in": %[1]d}]}`, checkedIn, 10-checkedIn, float64(checkedIn)/10)
+	})
+	c.AuthToken = "abc"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stats, errs := c.PollCheckinStats(ctx, 1, time.Millisecond)
+	for i := 1; i <= 2; i++ {
+		select {
+		case s := <-stats:
+			if s.CheckedIn != i || s.Remaining != 10-i || len(s.TicketStats) != 1 || s.TicketStats[0].CheckedIn != i {
+				t.Errorf("expected %d checked in; got %+v\n", i, s)
+			}
+		case err := <-errs:
+			t.Fatal(err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out")
+		}
+	}
+	cancel()
+	for range stats {
+	}
+	for range errs {
+	}
+
+	forbidden := new(ForbiddenError)
+	if _, err := c.GetEventCheckinStats(context.Background(), 2); !errors.As(err, &forbidden) {
+		t.Errorf("expected *ForbiddenError; got %v\n", err)
+	}
+}
@@ -0,0 +1,409 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BookingState is the state of a booking (see Booking.BookingState).
+type BookingState int
+
+const (
+	// StateUnknown is the state of bookings whose State is not recognised.
+	StateUnknown BookingState = iota
+	// StatePending bookings have been made but are awaiting payment confirmation.
+	StatePending
+	// StateConfirmed bookings have been paid for and are valid for entry.
+	StateConfirmed
+	// StateCancelled bookings have been cancelled, such as with CancelBooking.
+	StateCancelled
+	// StateRefunded bookings have been cancelled and refunded.
+	StateRefunded
+	// StateCheckedIn bookings have been used for entry to the event.
+	StateCheckedIn
+)
+
+var bookingStateNames = map[BookingState]string{
+	StateUnknown:   "unknown",
+	StatePending:   "pending",
+	StateConfirmed: "confirmed",
+	StateCancelled: "cancelled",
+	StateRefunded:  "refunded",
+	StateCheckedIn: "checked_in",
+}
+
+func (s BookingState) String() string {
+	if name, ok := bookingStateNames[s]; ok {
+		return name
+	}
+	if s == DryRunState {
+		return "dry_run"
+	}
+	return fmt.Sprintf("BookingState(%d)", int(s))
+}
+
+// BookingState returns the booking's State as a BookingState.
+func (b *Booking) BookingState() BookingState {
+	return BookingState(b.State)
+}
+
+// StateName returns the name of the booking's state (e.g. "confirmed"), for display.
+func (b *Booking) StateName() string {
+	return b.BookingState().String()
+}
+
+// UnmarshalJSON decodes a booking, accepting its state as either a number or a name
+// (e.g. "confirmed"). Unrecognised names are decoded as StateUnknown. If the API does not
+// give the TotalAmountPaid, it is calculated from the Price, BookingFee and Amount.
+func (b *Booking) UnmarshalJSON(data []byte) error {
+	type booking Booking
+	aux := struct {
+		*booking
+		State           json.RawMessage `json:"state"`
+		TotalAmountPaid *float64        `json:"total_amount_paid"`
+	}{booking: (*booking)(b)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	b.State = int(parseBookingState(aux.State))
+	if aux.TotalAmountPaid != nil {
+		b.TotalAmountPaid = *aux.TotalAmountPaid
+	} else {
+		b.TotalAmountPaid = (b.Price + b.BookingFee) * float64(b.Amount)
+	}
+	return nil
+}
+
+// IsFree reports whether nothing was paid for the booking.
+func (b *Booking) IsFree() bool {
+	return moneyEqual(b.TotalAmountPaid, 0)
+}
+
+// Receipt returns a plain-text summary of the amount paid for the booking, for display.
+func (b *Booking) Receipt() string {
+	format := func(amount float64) string {
+		return strings.TrimSpace(fmt.Sprintf("%.2f %s", amount, b.Currency))
+	}
+	lines := []string{fmt.Sprintf("Booking %d: %s", b.ID, b.Event.Name)}
+	if b.IsFree() {
+		return strings.Join(append(lines, fmt.Sprintf("Tickets: %d (free)", b.Amount)), "\n")
+	}
+	lines = append(lines, fmt.Sprintf("Tickets: %d x %s", b.Amount, format(b.Price)))
+	if !moneyEqual(b.BookingFee, 0) {
+		lines = append(lines, fmt.Sprintf("Booking fees: %d x %s", b.Amount, format(b.BookingFee)))
+	}
+	return strings.Join(append(lines, "Total paid: "+format(b.TotalAmountPaid)), "\n")
+}
+
+func parseBookingState(raw json.RawMessage) BookingState {
+	var state int
+	if err := json.Unmarshal(raw, &state); err == nil {
+		return BookingState(state)
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return StateUnknown
+	}
+	if state, err := strconv.Atoi(name); err == nil {
+		return BookingState(state)
+	}
+	for state, stateName := range bookingStateNames {
+		if strings.EqualFold(name, stateName) || strings.EqualFold(strings.ReplaceAll(name, "-", "_"), stateName) {
+			return state
+		}
+	}
+	return StateUnknown
+}
+
+// refundPolicyNone is the RefundPolicy of bookings which can never be refunded.
+const refundPolicyNone = "non-refundable"
+
+// defaultRefundWindow is how long before the event starts refunds are allowed
+// when the API does not give a RefundDeadline.
+const defaultRefundWindow = 24 * time.Hour
+
+// IsRefundable reports whether the booking can be refunded (see CancelBooking). Active
+// bookings are refundable until their RefundDeadline or, if it is not given, until 24 hours
+// before the event starts, unless the RefundPolicy is "non-refundable". This is advisory
+// only; the API decides whether a cancellation is accepted.
+func (b *Booking) IsRefundable() bool {
+	if b.RefundPolicy == refundPolicyNone || b.CheckedIn {
+		return false
+	}
+	if state := b.BookingState(); state != StatePending && state != StateConfirmed {
+		return false
+	}
+	if !b.RefundDeadline.IsZero() {
+		return time.Now().Before(b.RefundDeadline)
+	}
+	return time.Until(b.Event.StartTime) > defaultRefundWindow
+}
+
+// CancelBooking cancels the booking with the given ID, returning the updated booking.
+// Bookings that are non-refundable or past their CancellationDeadline are rejected by
+// the API; a *CancelNotAllowedError is returned if the booking has been checked in.
+func (c *Client) CancelBooking(bookingID int) (*Booking, error) {
+	return c.CancelBookingWithContext(context.Background(), bookingID)
+}
+
+// CancelBookingWithContext is like CancelBooking but uses ctx for the underlying HTTP request.
+func (c *Client) CancelBookingWithContext(ctx context.Context, bookingID int) (*Booking, error) {
+	booking := Booking{}
+	if err := c.post(ctx, c.url(cancelPath, bookingID), new(bytes.Buffer), true, &booking); err != nil {
+		if errorCode(err) == codeBookingCheckedIn {
+			return nil, &CancelNotAllowedError{*asAPIError(err)}
+		}
+		return nil, errors.Wrap(err, "error cancelling booking")
+	}
+	return &booking, nil
+}
+
+// GetBookingByID returns the full details of the user's booking with the given ID.
+// A *ForbiddenError will be returned if the booking belongs to another user.
+func (c *Client) GetBookingByID(ctx context.Context, id int) (*Booking, error) {
+	booking := Booking{}
+	if err := c.get(ctx, c.url(bookingIDPath, id), true, &booking); err != nil {
+		return nil, errors.Wrap(err, "error getting booking")
+	}
+	return &booking, nil
+}
+
+// ResendBookingConfirmation asks FIXR to email the confirmation (and PDF) of the booking
+// with the given ID to the user again. A *NotFoundError will be returned if the booking does
+// not exist. Resends are rate limited by the API; a *TooManyRequestsError will be returned if
+// too many have been requested recently, with RetryAfter set when the API gives it.
+func (c *Client) ResendBookingConfirmation(ctx context.Context, bookingID int) error {
+	if err := c.post(ctx, c.url(resendPath, bookingID), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error resending booking confirmation")
+	}
+	return nil
+}
+
+// BookingList contains a single page of the user's bookings.
+type BookingList struct {
+	apiError
+	Items       []Booking `json:"results"`
+	TotalCount  int       `json:"count"`
+	HasNextPage bool      `json:"-"`
+}
+
+type bookingPage struct {
+	BookingList
+	Next *string `json:"next"`
+}
+
+func (p *bookingPage) setLinks(links map[string]string) {
+	if next, ok := links["next"]; ok {
+		p.Next = &next
+	}
+}
+
+// historyURL returns the URL of the given page of the user's bookings.
+func (c *Client) historyURL(page, pageSize int) (string, error) {
+	if page < 1 || pageSize < 1 {
+		return "", errors.New("page and page size must be positive")
+	}
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+	return c.url(historyPath) + "?" + query.Encode(), nil
+}
+
+// BookingHistoryPage is like GetBookingHistoryWithContext, but returns the page as a
+// *PaginatedResponse, from which the following pages can be fetched with FetchNextPage.
+func (c *Client) BookingHistoryPage(ctx context.Context, page, pageSize int) (*PaginatedResponse[Booking], error) {
+	addr, err := c.historyURL(page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := PaginatedResponse[Booking]{}
+	if err := c.get(ctx, addr, true, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting booking history")
+	}
+	return &resp, nil
+}
+
+// GetBookingHistory returns the given page (starting at 1) of the user's past and upcoming bookings.
+// An error will be returned if one is encountered.
+//
+// GetBookingHistory is superseded by BookingHistoryPage, which also returns the links to the
+// previous and next pages; it is kept, returning a *BookingList, for compatibility.
+func (c *Client) GetBookingHistory(page, pageSize int) (*BookingList, error) {
+	return c.GetBookingHistoryWithContext(context.Background(), page, pageSize)
+}
+
+// GetBookingHistoryWithContext is like GetBookingHistory but uses ctx for the underlying HTTP request.
+func (c *Client) GetBookingHistoryWithContext(ctx context.Context, page, pageSize int) (*BookingList, error) {
+	addr, err := c.historyURL(page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := bookingPage{}
+	if err := c.get(ctx, addr, true, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting booking history")
+	}
+	resp.HasNextPage = resp.Next != nil
+	return &resp.BookingList, nil
+}
+
+const historyPageSize = 50
+
+// AllBookings returns every booking made by the user, fetching each page of the history in turn.
+func (c *Client) AllBookings() ([]Booking, error) {
+	return c.AllBookingsWithContext(context.Background())
+}
+
+// AllBookingsWithContext is like AllBookings but uses ctx for the underlying HTTP requests.
+func (c *Client) AllBookingsWithContext(ctx context.Context) ([]Booking, error) {
+	var bookings []Booking
+	it := c.IterateBookings(BookingFilter{})
+	for it.Next(ctx) {
+		bookings = append(bookings, it.Value()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// DownloadPDF writes the PDF ticket for booking b to dst. The user's auth token is only sent
+// if the PDF is hosted by the FIXR API (see WithBaseURL).
+// An error will be returned if the booking has no PDF or if one is encountered.
+func (c *Client) DownloadPDF(ctx context.Context, b *Booking, dst io.Writer) error {
+	if len(b.PDF) == 0 {
+		return errors.New("booking has no PDF")
+	}
+	resp, err := c.download(ctx, b.PDF)
+	if err != nil {
+		return errors.Wrap(err, "error downloading PDF")
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(dst, resp.Body); err != nil {
+		return errors.Wrap(err, "error downloading PDF")
+	}
+	return nil
+}
+
+// DownloadPDFToFile writes the PDF ticket for booking b to a file at path.
+// The file is removed if the download fails.
+func (c *Client) DownloadPDFToFile(ctx context.Context, b *Booking, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "error creating PDF file")
+	}
+	err = c.DownloadPDF(ctx, b, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = errors.Wrap(closeErr, "error writing PDF file")
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// BookOption configures a single call to Book.
+type BookOption func(*bookOptions)
+
+type bookOptions struct {
+	skipRefresh bool
+	purchaseKey string
+	ctx         context.Context
+	timeout     time.Duration
+}
+
+// WithoutTicketRefresh skips the API call made by Book to check that the ticket is on sale.
+// It is intended for callers who have only just fetched the ticket's event.
+func WithoutTicketRefresh() BookOption {
+	return func(o *bookOptions) {
+		o.skipRefresh = true
+	}
+}
+
+// WithPurchaseKey sends key (see NewPurchaseKey) as the booking's purchase key, which the API
+// uses to deduplicate payments, in place of a newly generated one. If a booking fails without
+// an *APIError (e.g. with a network timeout), the payment may still have been taken, so Book
+// should be retried with the same key, ticket, amount and promo code. Retrying is then safe:
+// an *AlreadyBookedError will be returned if the earlier attempt succeeded, in which case the
+// booking can be found with GetBookingHistory.
+func WithPurchaseKey(key string) BookOption {
+	return func(o *bookOptions) {
+		o.purchaseKey = key
+	}
+}
+
+// NewPurchaseKey returns a random purchase key for use with WithPurchaseKey.
+func NewPurchaseKey() (string, error) {
+	return genKey()
+}
+
+// WithBookTimeout limits the time taken by the API calls made by Book to d,
+// e.g. to allow paid bookings longer than the client's timeout.
+func WithBookTimeout(d time.Duration) BookOption {
+	return func(o *bookOptions) {
+		o.timeout = d
+	}
+}
+
+// WithBookContext makes Book use ctx for its API calls, in place of the one given to
+// BookWithContext (or context.Background).
+func WithBookContext(ctx context.Context) BookOption {
+	return func(o *bookOptions) {
+		o.ctx = ctx
+	}
+}
+
+type ticketStatus struct {
+	apiError
+	Ticket
+	ValidFrom time.Time `json:"valid_from"`
+}
+
+// checkTicketValid fetches the live state of a ticket, returning a *TicketNotYetValidError
+// if it is not yet on sale.
+func (c *Client) checkTicketValid(ctx context.Context, ticketID int) error {
+	status := ticketStatus{}
+	if err := c.get(ctx, c.url(ticketPath, ticketID), false, &status); err != nil {
+		return errors.Wrap(err, "error checking ticket")
+	}
+	if status.Invalid {
+		return &TicketNotYetValidError{TicketID: ticketID, ValidFrom: status.ValidFrom}
+	}
+	return nil
+}
+
+type shareResponse struct {
+	apiError
+	ShareURL string `json:"share_url"`
+}
+
+// BookingShareURL returns the URL for sharing the booking with the given ID on social media.
+// An empty string (and no error) is returned if the event does not allow sharing.
+func (c *Client) BookingShareURL(ctx context.Context, bookingID int) (string, error) {
+	resp := shareResponse{}
+	if err := c.get(ctx, c.url(sharePath, bookingID), true, &resp); err != nil {
+		if errorCode(err) == codeSharingDisabled {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "error getting share URL")
+	}
+	return resp.ShareURL, nil
+}
+
+// GenerateSocialShareText returns text for sharing the booking on social media, using its
+// ShareURL (see BookingShareURL). An empty string is returned if the booking has no ShareURL.
+func (c *Client) GenerateSocialShareText(b *Booking) string {
+	if len(b.ShareURL) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("I'm going to %s! Get tickets at %s", b.Event.Name, b.ShareURL)
+}
@@ -0,0 +1,412 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestCancelBooking(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/booking/7/cancel" {
+			t.Errorf("expected %s; got %s %s\n", "POST /booking/7/cancel", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"id": 7, "state": 2}`)
+	})
+	c.AuthToken = "abc"
+	b, err := c.CancelBooking(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != 7 || b.State != 2 {
+		t.Errorf("expected %d/%d; got %d/%d\n", 7, 2, b.ID, b.State)
+	}
+}
+
+func TestCancelBookingCheckedIn(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusForbidden} {
+		c := newTestClient(t, statusHandler(status, `{"message": "checked in", "code": "booking_checked_in"}`))
+		c.AuthToken = "abc"
+		_, err := c.CancelBooking(7)
+		cancelErr := new(CancelNotAllowedError)
+		if !errors.As(err, &cancelErr) {
+			t.Errorf("expected *CancelNotAllowedError; got %T (status: %d)\n", err, status)
+		} else if cancelErr.StatusCode != status {
+			t.Errorf("expected %d; got %d\n", status, cancelErr.StatusCode)
+		}
+	}
+}
+
+func TestGetBookingByID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/booking/7" {
+			t.Errorf("expected %s; got %s\n", "/booking/7", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"id": 7, "checked_in": true, "check_in_time": "2020-01-01T22:00:00Z", "qr_code_url": "https://fixr.co/qr/7"}`)
+	})
+	c.AuthToken = "abc"
+	b, err := c.GetBookingByID(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.CheckedIn || b.CheckInTime == nil || b.QRCodeURL != "https://fixr.co/qr/7" {
+		t.Errorf("expected checked in booking with QR code; got %+v\n", b)
+	}
+}
+
+func TestGetBookingByIDForbidden(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusForbidden, `{"detail": "Forbidden."}`))
+	c.AuthToken = "abc"
+	_, err := c.GetBookingByID(context.Background(), 7)
+	forbiddenErr, authErr := new(ForbiddenError), new(AuthError)
+	if !errors.As(err, &forbiddenErr) || !errors.As(err, &authErr) {
+		t.Fatalf("expected *ForbiddenError and *AuthError; got %T\n", errors.Cause(err))
+	}
+}
+
+func TestResendBookingConfirmation(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusTooManyRequests, `{"detail": "Too many resends."}`))
+	c.AuthToken = "abc"
+	err := c.ResendBookingConfirmation(context.Background(), 7)
+	tooManyErr := new(TooManyRequestsError)
+	if !errors.As(err, &tooManyErr) {
+		t.Fatalf("expected *TooManyRequestsError; got %T\n", errors.Cause(err))
+	}
+	if tooManyErr.RetryAfter.IsZero() {
+		t.Error("expected RetryAfter to be set")
+	}
+	c = newTestClient(t, statusHandler(http.StatusNotFound, `{"detail": "Not found."}`))
+	c.AuthToken = "abc"
+	notFoundErr := new(NotFoundError)
+	if err := c.ResendBookingConfirmation(context.Background(), 7); !errors.As(err, &notFoundErr) {
+		t.Errorf("expected *NotFoundError; got %v\n", err)
+	}
+}
+
+func TestAllBookings(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"count": 2, "next": "/bookings?page=2", "results": [{"id": 1, "created_at": "2017-06-01T20:00:00Z"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"count": 2, "next": null, "results": [{"id": 2}]}`)
+		default:
+			t.Errorf("unexpected page %s\n", r.URL.Query().Get("page"))
+		}
+	})
+	c.AuthToken = "abc"
+	bookings, err := c.AllBookings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookings) != 2 || bookings[0].ID != 1 || bookings[1].ID != 2 {
+		t.Fatalf("expected bookings 1 and 2; got %v\n", bookings)
+	}
+	if bookings[0].CreatedAt.Year() != 2017 {
+		t.Errorf("expected %d; got %d\n", 2017, bookings[0].CreatedAt.Year())
+	}
+}
+
+func TestDownloadPDF(t *testing.T) {
+	expected := "%PDF-1.4 test"
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Token abc" {
+			t.Errorf("expected %s; got %s\n", "Token abc", auth)
+		}
+		fmt.Fprint(w, expected)
+	})
+	c.AuthToken = "abc"
+	path := filepath.Join(t.TempDir(), "ticket.pdf")
+	if err := c.DownloadPDFToFile(context.Background(), &Booking{PDF: c.baseURL + "/ticket.pdf"}, path); err != nil {
+		t.Fatal(err)
+	}
+	result, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(result) != expected {
+		t.Errorf("expected %s; got %s\n", expected, result)
+	}
+}
+
+func TestDownloadPDFOtherHost(t *testing.T) {
+	expected := "%PDF-1.4 test"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, header := range []string{"Authorization", "FIXR-App-Version"} {
+			if value := r.Header.Get(header); len(value) > 0 {
+				t.Errorf("expected no %s header; got %s\n", header, value)
+			}
+		}
+		if r.URL.Path != "/ticket.pdf" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, expected)
+	}))
+	defer server.Close()
+	c := NewClient("test@example.com")
+	c.AuthToken = "abc"
+	result := new(bytes.Buffer)
+	if err := c.DownloadPDF(context.Background(), &Booking{PDF: server.URL + "/ticket.pdf"}, result); err != nil {
+		t.Fatal(err)
+	}
+	if result.String() != expected {
+		t.Errorf("expected %s; got %s\n", expected, result)
+	}
+	httpErr := new(HTTPError)
+	if err := c.DownloadPDF(context.Background(), &Booking{PDF: server.URL + "/missing.pdf"}, result); !errors.As(err, &httpErr) || httpErr.StatusCode() != http.StatusNotFound {
+		t.Errorf("expected a 404 *HTTPError; got %v\n", err)
+	}
+}
+
+func TestDownloadPDFMissing(t *testing.T) {
+	if err := NewClient("").DownloadPDF(context.Background(), &Booking{}, new(bytes.Buffer)); err == nil {
+		t.Error("expected download without PDF URL to fail")
+	}
+}
+
+func TestBookNotYetValid(t *testing.T) {
+	booked := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ticket/1":
+			fmt.Fprint(w, `{"id": 1, "not_yet_valid": true, "valid_from": "2030-01-01T12:00:00Z"}`)
+		case "/booking":
+			booked = true
+			fmt.Fprint(w, `{"id": 2}`)
+		}
+	})
+	c.AuthToken = "abc"
+	ticket := &Ticket{ID: 1, Max: 1}
+	_, err := c.Book(ticket, 1, nil)
+	validErr := new(TicketNotYetValidError)
+	if !errors.As(err, &validErr) {
+		t.Fatalf("expected *TicketNotYetValidError; got %v\n", err)
+	}
+	if validErr.ValidFrom.Year() != 2030 || booked {
+		t.Errorf("unexpected result: %+v (booked: %t)\n", validErr, booked)
+	}
+	if _, err := c.Book(ticket, 1, nil, WithoutTicketRefresh()); err != nil || !booked {
+		t.Errorf("expected booking without refresh to succeed; got %v\n", err)
+	}
+}
+
+func TestBookTicketGuards(t *testing.T) {
+	c := NewClient("test@example.com")
+	c.DryRun = true
+	var soldOut *SoldOutError
+	if _, err := c.Book(&Ticket{ID: 1, Max: 1, SoldOut: true, Expired: true}, 1, nil); !errors.As(err, &soldOut) || soldOut.TicketID != 1 {
+		t.Errorf("expected *SoldOutError for ticket 1; got %v\n", err)
+	}
+	var expired *ExpiredError
+	if _, err := c.Book(&Ticket{ID: 2, Max: 1, Expired: true}, 1, nil); !errors.As(err, &expired) || expired.TicketID != 2 {
+		t.Errorf("expected *ExpiredError for ticket 2; got %v\n", err)
+	}
+	if _, err := c.Book(&Ticket{ID: 4, Max: 4, MaxPerOrder: 2}, 3, nil); err == nil {
+		t.Error("expected booking above the maximum per order to fail")
+	}
+	for _, test := range []struct {
+		ticket *Ticket
+		amount int
+	}{
+		{&Ticket{ID: 5, Max: 1}, 0},
+		{&Ticket{ID: 5, Max: 1}, -1},
+		{&Ticket{ID: 5, Max: 1}, 2},
+		{&Ticket{ID: 5, Min: 2, Max: 4}, 1},
+	} {
+		var validation *ValidationError
+		if _, err := c.Book(test.ticket, test.amount, nil); !errors.As(err, &validation) || validation.Field != "amount" {
+			t.Errorf("expected *ValidationError for amount %d; got %v\n", test.amount, err)
+		}
+	}
+	c.DryRun = false
+	var notYetValid *TicketNotYetValidError
+	if _, err := c.Book(&Ticket{ID: 3, Max: 1, Invalid: true}, 1, nil, WithoutTicketRefresh()); !errors.As(err, &notYetValid) || notYetValid.TicketID != 3 {
+		t.Errorf("expected *TicketNotYetValidError for ticket 3; got %v\n", err)
+	}
+}
+
+func TestBookingIsRefundable(t *testing.T) {
+	now := time.Now()
+	for _, test := range []struct {
+		booking  Booking
+		expected bool
+	}{
+		{Booking{State: int(StateConfirmed), Event: Event{StartTime: now.Add(48 * time.Hour)}}, true},
+		{Booking{State: int(StateConfirmed), Event: Event{StartTime: now.Add(12 * time.Hour)}}, false},
+		{Booking{State: int(StateConfirmed), RefundDeadline: now.Add(time.Hour), Event: Event{StartTime: now.Add(2 * time.Hour)}}, true},
+		{Booking{State: int(StateConfirmed), RefundDeadline: now.Add(-time.Hour), Event: Event{StartTime: now.Add(48 * time.Hour)}}, false},
+		{Booking{State: int(StateConfirmed), RefundPolicy: "non-refundable", Event: Event{StartTime: now.Add(48 * time.Hour)}}, false},
+		{Booking{State: DryRunState, Event: Event{StartTime: now.Add(48 * time.Hour)}}, false},
+	} {
+		if result := test.booking.IsRefundable(); result != test.expected {
+			t.Errorf("expected %t; got %t (%+v)\n", test.expected, result, test.booking)
+		}
+	}
+}
+
+func TestCallTimeouts(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	c.AuthToken = "abc"
+	if _, err := c.Book(&Ticket{ID: 1, Max: 1}, 1, nil, WithBookTimeout(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v; got %v\n", context.DeadlineExceeded, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Book(&Ticket{ID: 1, Max: 1}, 1, nil, WithBookContext(ctx)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v; got %v\n", context.Canceled, err)
+	}
+	if _, err := c.Event(1, WithEventTimeout(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v; got %v\n", context.DeadlineExceeded, err)
+	}
+	if _, err := c.Promo(1, "CODE", WithPromoTimeout(10*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v; got %v\n", context.DeadlineExceeded, err)
+	}
+}
+
+func TestBookWithPurchaseKey(t *testing.T) {
+	key, err := NewPurchaseKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	booked, conflictStatus := false, http.StatusConflict
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var pl struct {
+			PurchaseKey string `json:"purchase_key"`
+		}
+		json.NewDecoder(r.Body).Decode(&pl)
+		if pl.PurchaseKey != key {
+			t.Errorf("expected %s; got %s\n", key, pl.PurchaseKey)
+		}
+		if booked {
+			w.WriteHeader(conflictStatus)
+			fmt.Fprint(w, `{"message": "already booked", "code": "purchase_key_used"}`)
+			return
+		}
+		booked = true
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+	c.AuthToken = "abc"
+	ticket := &Ticket{ID: 1, Max: 1, Price: 10}
+	b, err := c.Book(ticket, 1, nil, WithPurchaseKey(key), WithoutTicketRefresh())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.IdempotencyKey != key {
+		t.Errorf("expected %s; got %s\n", key, b.IdempotencyKey)
+	}
+	alreadyBookedErr := new(AlreadyBookedError)
+	if _, err := c.Book(ticket, 1, nil, WithPurchaseKey(key), WithoutTicketRefresh()); !errors.As(err, &alreadyBookedErr) {
+		t.Errorf("expected *AlreadyBookedError; got %v\n", err)
+	}
+	conflictStatus = http.StatusForbidden
+	if _, err := c.Book(ticket, 1, nil, WithPurchaseKey(key), WithoutTicketRefresh()); !errors.As(err, &alreadyBookedErr) {
+		t.Errorf("expected *AlreadyBookedError; got %v\n", err)
+	}
+}
+
+func TestBookingState(t *testing.T) {
+	for _, test := range []struct {
+		json     string
+		expected BookingState
+	}{
+		{`{"state": 2}`, StateConfirmed},
+		{`{"state": "3"}`, StateCancelled},
+		{`{"state": "refunded"}`, StateRefunded},
+		{`{"state": "CHECKED_IN"}`, StateCheckedIn},
+		{`{"state": "checked-in"}`, StateCheckedIn},
+		{`{"state": "on_hold"}`, StateUnknown},
+		{`{}`, StateUnknown},
+	} {
+		b := Booking{}
+		if err := json.Unmarshal([]byte(test.json), &b); err != nil {
+			t.Fatal(err)
+		}
+		if b.BookingState() != test.expected || b.StateName() != test.expected.String() {
+			t.Errorf("expected %s; got %s (%s)\n", test.expected, b.BookingState(), test.json)
+		}
+	}
+	if name := (&Booking{State: DryRunState}).StateName(); name != "dry_run" {
+		t.Errorf("expected %s; got %s\n", "dry_run", name)
+	}
+}
+
+func TestBookingShareURL(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/booking/1/share":
+			fmt.Fprint(w, `{"share_url": "https://fixr.co/s/abc"}`)
+		case "/booking/2/share":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "sharing is disabled", "code": "sharing_disabled"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c.AuthToken = "abc"
+	shareURL, err := c.BookingShareURL(context.Background(), 1)
+	if err != nil || shareURL != "https://fixr.co/s/abc" {
+		t.Errorf("expected %s; got %s (%v)\n", "https://fixr.co/s/abc", shareURL, err)
+	}
+	if shareURL, err := c.BookingShareURL(context.Background(), 2); err != nil || len(shareURL) > 0 {
+		t.Errorf("expected no share URL; got %s (%v)\n", shareURL, err)
+	}
+	if _, err := c.BookingShareURL(context.Background(), 3); err == nil {
+		t.Error("expected an error for a missing booking")
+	}
+
+	b := &Booking{Event: Event{Name: "Summer Ball 2017"}, ShareURL: shareURL}
+	if text, expected := c.GenerateSocialShareText(b), "I'm going to Summer Ball 2017! Get tickets at https://fixr.co/s/abc"; text != expected {
+		t.Errorf("expected %s; got %s\n", expected, text)
+	}
+	b.ShareURL = ""
+	if text := c.GenerateSocialShareText(b); len(text) > 0 {
+		t.Errorf("expected no share text; got %s\n", text)
+	}
+}
+
+func TestBookingTotalAmountPaid(t *testing.T) {
+	for _, test := range []struct {
+		json     string
+		expected float64
+		free     bool
+	}{
+		{`{"total_amount_paid": 49.5, "price": 10, "amount": 1}`, 49.5, false},
+		{`{"price": 45, "booking_fee": 4.5, "amount": 2}`, 99, false},
+		{`{"price": 0.1, "booking_fee": 0.2, "amount": 3}`, 0.9, false},
+		{`{"total_amount_paid": 0, "price": 10, "amount": 1}`, 0, true},
+		{`{"amount": 2}`, 0, true},
+	} {
+		b := Booking{}
+		if err := json.Unmarshal([]byte(test.json), &b); err != nil {
+			t.Fatal(err)
+		}
+		if !moneyEqual(b.TotalAmountPaid, test.expected) || b.IsFree() != test.free {
+			t.Errorf("expected %.2f (free: %t); got %.2f (free: %t) (%s)\n", test.expected, test.free, b.TotalAmountPaid, b.IsFree(), test.json)
+		}
+	}
+}
+
+func TestBookingReceipt(t *testing.T) {
+	b := &Booking{ID: 7, Event: Event{Name: "Summer Ball 2017"}, Amount: 2, Price: 45, BookingFee: 4.5, TotalAmountPaid: 99, Currency: "GBP"}
+	expected := "Booking 7: Summer Ball 2017\nTickets: 2 x 45.00 GBP\nBooking fees: 2 x 4.50 GBP\nTotal paid: 99.00 GBP"
+	if receipt := b.Receipt(); receipt != expected {
+		t.Errorf("expected %q; got %q\n", expected, receipt)
+	}
+	b = &Booking{ID: 8, Event: Event{Name: "Freshers"}, Amount: 1}
+	expected = "Booking 8: Freshers\nTickets: 1 (free)"
+	if receipt := b.Receipt(); receipt != expected {
+		t.Errorf("expected %q; got %q\n", expected, receipt)
+	}
+}
@@ -0,0 +1,66 @@
+package fixr
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type cacheEntry struct {
+	event     *Event
+	fetchedAt time.Time
+}
+
+// WithEventCacheTTL caches responses from Event for d, so that repeated lookups of the
+// same event (e.g. when polling) do not each make an HTTP request. Caching is disabled by default.
+func WithEventCacheTTL(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d < 0 {
+			return errors.New("cache TTL cannot be negative")
+		}
+		c.eventCacheTTL = d
+		return nil
+	}
+}
+
+// copyEvent returns a copy of e which can be modified without altering the cached event.
+func copyEvent(e *Event) *Event {
+	event := *e
+	event.Tickets = append([]Ticket(nil), e.Tickets...)
+	return &event
+}
+
+func (c *Client) cachedEvent(id int) (*Event, bool) {
+	if c.eventCacheTTL <= 0 {
+		return nil, false
+	}
+	value, ok := c.eventCache.Load(id)
+	if !ok {
+		return nil, false
+	}
+	entry := value.(cacheEntry)
+	if time.Since(entry.fetchedAt) >= c.eventCacheTTL {
+		c.eventCache.Delete(id)
+		return nil, false
+	}
+	return copyEvent(entry.event), true
+}
+
+func (c *Client) cacheEvent(e *Event) {
+	if c.eventCacheTTL > 0 {
+		c.eventCache.Store(e.ID, cacheEntry{event: copyEvent(e), fetchedAt: time.Now()})
+	}
+}
+
+// InvalidateEventCache evicts the event with the given ID from the cache.
+func (c *Client) InvalidateEventCache(id int) {
+	c.eventCache.Delete(id)
+}
+
+// ClearEventCache evicts every event from the cache.
+func (c *Client) ClearEventCache() {
+	c.eventCache.Range(func(key, _ interface{}) bool {
+		c.eventCache.Delete(key)
+		return true
+	})
+}
@@ -0,0 +1,119 @@
+package fixr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventCache(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{"id": 1, "tickets": [{"id": 2}]}`)
+	}, WithEventCacheTTL(time.Minute))
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Event(1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	e, err := c.Event(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Tickets[0].SoldOut = true
+	if e, _ = c.Event(1); e.Tickets[0].SoldOut {
+		t.Error("cached event should not be modified by callers")
+	}
+	before := atomic.LoadInt32(&calls)
+	c.InvalidateEventCache(1)
+	c.Event(1)
+	c.ClearEventCache()
+	c.Event(1)
+	if after := atomic.LoadInt32(&calls); after != before+2 {
+		t.Errorf("expected %d requests; got %d\n", before+2, after)
+	}
+}
+
+func TestEventRequestDeduplication(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprint(w, `{"id": 42, "tickets": []}`)
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if e, err := c.Event(42); err != nil || e.ID != 42 {
+				t.Errorf("expected event %d; got %v (%v)\n", 42, e, err)
+			}
+		}()
+	}
+	// Give every goroutine time to join the in-flight request before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if requests != 1 {
+		t.Errorf("expected %d; got %d\n", 1, requests)
+	}
+}
+
+func TestSharedRequestLeaderCancelled(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		if strings.HasPrefix(r.URL.Path, "/promo_code/") {
+			fmt.Fprint(w, `{"code": "CODE", "remaining": 1}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 42, "tickets": []}`)
+	})
+	c.AuthToken = "abc"
+	for _, fetch := range []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			_, err := c.EventWithContext(ctx, 42)
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := c.PromoWithContext(ctx, 1, "CODE")
+			return err
+		},
+	} {
+		leaderCtx, cancel := context.WithCancel(context.Background())
+		leaderErr, followerErr := make(chan error, 1), make(chan error, 1)
+		go func() { leaderErr <- fetch(leaderCtx) }()
+		// Give the leader time to start the request before the follower joins it.
+		time.Sleep(20 * time.Millisecond)
+		go func() { followerErr <- fetch(context.Background()) }()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+			t.Errorf("expected %v; got %v\n", context.Canceled, err)
+		}
+		release <- struct{}{}
+		if err := <-followerErr; err != nil {
+			t.Errorf("expected the follower to succeed; got %v\n", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected %d requests; got %d\n", 2, requests)
+	}
+}
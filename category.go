@@ -0,0 +1,42 @@
+package fixr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// categoryCacheTTL is how long the list of categories is cached, as it rarely changes.
+const categoryCacheTTL = 24 * time.Hour
+
+// Category is a kind of event (e.g. Music or Comedy), used to filter events by CategoryID.
+type Category struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	IconURL string `json:"icon_url"`
+}
+
+type categoryCache struct {
+	mu         sync.Mutex
+	categories []Category
+	fetchedAt  time.Time
+}
+
+// GetEventCategories returns the categories of events listed on FIXR.
+// The categories are cached by the client for 24 hours.
+func (c *Client) GetEventCategories(ctx context.Context) ([]Category, error) {
+	c.categories.mu.Lock()
+	defer c.categories.mu.Unlock()
+	if c.categories.categories != nil && time.Since(c.categories.fetchedAt) < categoryCacheTTL {
+		return append([]Category(nil), c.categories.categories...), nil
+	}
+	categories := []Category{}
+	if err := c.get(ctx, c.url(categoryPath), false, &listResponse{items: &categories}); err != nil {
+		return nil, errors.Wrap(err, "error getting event categories")
+	}
+	c.categories.categories, c.categories.fetchedAt = categories, time.Now()
+	return append([]Category(nil), categories...), nil
+}
@@ -0,0 +1,41 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetEventCategories(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `[{"id": 1, "name": "Music", "slug": "music"}]`)
+	})
+	for i := 0; i < 2; i++ {
+		categories, err := c.GetEventCategories(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(categories) != 1 || categories[0].Slug != "music" {
+			t.Errorf("expected %s; got %v\n", "music", categories)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected %d; got %d\n", 1, requests)
+	}
+}
+
+func TestGetEventCategoriesEmpty(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	categories, err := c.GetEventCategories(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 0 {
+		t.Errorf("expected no categories; got %v\n", categories)
+	}
+}
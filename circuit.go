@@ -0,0 +1,137 @@
+package fixr
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultRecoveryTimeout  = 30 * time.Second
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+// The states of a CircuitBreaker.
+const (
+	// CircuitClosed allows all requests.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all requests until the recovery timeout has passed.
+	CircuitOpen
+	// CircuitHalfOpen allows a single trial request to determine whether to close the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitOpenError is returned, without making a request, while the circuit breaker is open.
+type CircuitOpenError struct {
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open (retry at %s)", e.RetryAt.Format(time.RFC3339))
+}
+
+// CircuitBreaker stops requests being made to the FIXR API after a number of consecutive
+// failures (network errors or 5xx responses). After the recovery timeout, a single trial
+// request is allowed: the circuit closes if it succeeds, and opens again if it fails.
+// It is safe for concurrent use.
+type CircuitBreaker struct {
+	mu              sync.Mutex
+	threshold       int
+	recoveryTimeout time.Duration
+	state           CircuitState
+	failures        int
+	openedAt        time.Time
+	probing         bool
+}
+
+// NewCircuitBreaker returns a closed *CircuitBreaker which opens after threshold consecutive failures
+// and stays open for recoveryTimeout.
+func NewCircuitBreaker(threshold int, recoveryTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, recoveryTimeout: recoveryTimeout}
+}
+
+// WithCircuitBreaker guards the client's requests with a CircuitBreaker. Zero values
+// use the defaults (a threshold of 5 failures and a recovery timeout of 30s).
+func WithCircuitBreaker(threshold int, recoveryTimeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if threshold < 0 || recoveryTimeout < 0 {
+			return errors.New("circuit breaker threshold and timeout cannot be negative")
+		}
+		if threshold == 0 {
+			threshold = defaultFailureThreshold
+		}
+		if recoveryTimeout == 0 {
+			recoveryTimeout = defaultRecoveryTimeout
+		}
+		c.breaker = NewCircuitBreaker(threshold, recoveryTimeout)
+		return nil
+	}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.recoveryTimeout {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow returns a *CircuitOpenError if a request should not be made.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.recoveryTimeout {
+		b.state = CircuitHalfOpen
+	}
+	switch {
+	case b.state == CircuitOpen:
+		return &CircuitOpenError{RetryAt: b.openedAt.Add(b.recoveryTimeout)}
+	case b.state == CircuitHalfOpen && b.probing:
+		return &CircuitOpenError{RetryAt: time.Now()}
+	case b.state == CircuitHalfOpen:
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the circuit with the outcome of an allowed request.
+func (b *CircuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if success {
+		b.state, b.failures = CircuitClosed, 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state, b.openedAt = CircuitOpen, time.Now()
+	}
+}
+
+// cancel releases an allowed request which was abandoned by the caller, without
+// counting it as a success or failure.
+func (b *CircuitBreaker) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,55 @@
+package fixr
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	b := NewCircuitBreaker(2, 10*time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("expected closed circuit to allow request; got %v\n", err)
+		}
+		b.record(false)
+	}
+	if state := b.State(); state != CircuitOpen {
+		t.Fatalf("expected %v; got %v\n", CircuitOpen, state)
+	}
+	if err := b.allow(); err == nil {
+		t.Fatal("expected open circuit to reject request")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected half-open circuit to allow trial request; got %v\n", err)
+	}
+	if err := b.allow(); err == nil {
+		t.Fatal("expected half-open circuit to reject concurrent request")
+	}
+	b.record(true)
+	if state := b.State(); state != CircuitClosed {
+		t.Errorf("expected %v; got %v\n", CircuitClosed, state)
+	}
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithCircuitBreaker(3, time.Minute))
+	for i := 0; i < 5; i++ {
+		c.Event(1)
+	}
+	if calls != 3 {
+		t.Errorf("expected %d requests; got %d\n", 3, calls)
+	}
+	openErr := new(CircuitOpenError)
+	if _, err := c.Event(1); !errors.As(err, &openErr) {
+		t.Errorf("expected *CircuitOpenError; got %v\n", err)
+	}
+}
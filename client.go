@@ -2,23 +2,80 @@ package fixr
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
+// DefaultBaseURL is the root of FIXR's web API, used unless WithBaseURL is given.
+const DefaultBaseURL = "https://api.fixr-app.com/api/v2/app"
+
 const (
-	homeURL    = "https://fixr.co"
-	bookingURL = "https://api.fixr-app.com/api/v2/app/booking"
-	promoURL   = "https://api.fixr-app.com/api/v2/app/promo_code/%d/%s"
-	loginURL   = "https://api.fixr-app.com/api/v2/app/user/authenticate/with-email"
-	eventURL   = "https://api.fixr-app.com/api/v2/app/event/%d"
-	cardURL    = "https://api.stripe.com/v1/tokens"
-	tokenURL   = "https://api.fixr-app.com/api/v2/app/stripe"
-	meURL      = "https://api.fixr-app.com/api/v2/app/user/me"
+	homeURL = "https://fixr.co"
+	cardURL = "https://api.stripe.com/v1/tokens"
+
+	bookingPath   = "/booking"
+	bookingIDPath = "/booking/%d"
+	cancelPath    = "/booking/%d/cancel"
+	resendPath    = "/booking/%d/resend"
+	sharePath     = "/booking/%d/share"
+	historyPath   = "/bookings"
+	transferPath  = "/booking/%d/transfer"
+	acceptPath    = "/booking/transfer/accept"
+	promoPath     = "/promo_code/%d/%s"
+	loginPath     = "/user/authenticate/with-email"
+	signupPath    = "/user/register"
+	eventPath     = "/event/%d"
+	newEventPath  = "/event"
+	slugPath      = "/event/slug/%s"
+	ticketPath    = "/ticket/%d"
+	ticketsPath   = "/event/%d/tickets"
+	waitlistPath  = "/ticket/%d/waitlist"
+	venuePath     = "/venue/%d"
+	organizerPath = "/event/%d/organiser"
+	revenuePath   = "/event/%d/revenue"
+	attendeesPath = "/event/%d/attendees"
+	checkInPath   = "/booking/%d/check-in"
+	followPath    = "/organiser/%d/follow"
+	searchPath    = "/events/search"
+	batchPath     = "/events/batch"
+	featuredPath  = "/events/featured"
+	upcomingPath  = "/events/upcoming"
+	categoryPath  = "/events/categories"
+	recommendPath = "/events/recommended"
+	tokenPath     = "/stripe"
+	cardPath      = "/stripe/card/%s"
+	defaultPath   = "/stripe/card/%s/default"
+	mePath        = "/user/me"
+	statsPath     = "/user/stats"
+	avatarPath    = "/user/avatar"
+	logoutPath    = "/user/logout"
+	favoritesPath = "/user/favorites"
+	favoritePath  = "/user/favorites/%d"
+
+	passwordPath      = "/user/password"
+	resetRequestPath  = "/user/password/reset-request"
+	resetPasswordPath = "/user/password/reset"
+	stripeConfigPath  = "/stripe/config"
+	eventPromoPath    = "/event/%d/promo_code/%s"
+	versionPath       = "/version"
+	appleLoginPath    = "/user/authenticate/with-apple"
+	checkInStatsPath  = "/event/%d/checkin/stats"
+	notificationsPath = "/notifications"
+	notificationPath  = "/notifications/%d/read"
+	readAllPath       = "/notifications/read-all"
+	deleteUserPath    = "/user/delete"
+	dataExportPath    = "/user/data-export"
 )
 
 var (
@@ -54,24 +111,81 @@ func (a *apiError) clearError() {
 	a.Error = ""
 }
 
+// listResponse decodes a JSON array response into items (a pointer to a slice),
+// or an error response into apiError.
+type listResponse struct {
+	apiError
+	items interface{}
+}
+
+func (l *listResponse) UnmarshalJSON(data []byte) error {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, l.items)
+	}
+	return json.Unmarshal(data, &l.apiError)
+}
+
 // Client provides access to the FIXR API methods.
 type Client struct {
 	apiError
 	Email      string
+	DryRun     bool
 	FirstName  string      `json:"first_name"`
 	LastName   string      `json:"last_name"`
 	MagicURL   string      `json:"magic_login_url"`
 	AuthToken  string      `json:"auth_token"`
 	StripeUser *stripeUser `json:"stripe_user"`
+	Phone      string      `json:"phone"`
+	AvatarURL  string      `json:"avatar_url"`
 	httpClient *http.Client
+	connPool   *connPool
+	baseURL    string
+	userAgent  string
+	locale     string
+	currency   string
+	timeout    time.Duration
+	maxRetries int
+	retryDelay time.Duration
+	transports []func(http.RoundTripper) http.RoundTripper
+	tracing    tracing
+	breaker    *CircuitBreaker
+	logger     Logger
+
+	concurrency int
+	rateLimit   *rateLimitState
+
+	flight        *flightGroup
+	eventCache    *sync.Map
+	eventCacheTTL time.Duration
+	categories    *categoryCache
+	recommended   *recommendationCache
+	stripeKey     *stripeKeyCache
+
+	requestIDFunc        func() string
+	onVersionChanged     func(old, new string)
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+
+	// innerTransport, if set, wraps the base transport before any of the transports.
+	innerTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // Event contains the event details for given event ID.
 type Event struct {
-	ID      int      `json:"id"`
-	Name    string   `json:"name"`
-	Tickets []Ticket `json:"tickets"`
-	Error   string   `json:"detail"`
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Venue       Venue     `json:"venue"`
+	OrganizerID int       `json:"organiser_id"`
+	Tickets     []Ticket  `json:"tickets"` // The first page of tickets for events with many ticket types (see AllTickets)
+	Description string    `json:"description"`
+	MinAge      int       `json:"minimum_age"`
+	ImageURL    string    `json:"image"`
+	Website     string    `json:"website"`
+	IsFavorited bool      `json:"is_favorited"`
+	Slug        string    `json:"slug"`
+	Error       string    `json:"detail"`
 }
 
 func (e *Event) error() error {
@@ -93,10 +207,16 @@ type Ticket struct {
 	Currency   string  `json:"currency"`
 	Price      float64 `json:"price"`
 	BookingFee float64 `json:"booking_fee"`
+	Min        int     `json:"min_per_user"`
 	Max        int     `json:"max_per_user"`
 	SoldOut    bool    `json:"sold_out"`
 	Expired    bool    `json:"expired"`
 	Invalid    bool    `json:"not_yet_valid"`
+
+	SaleStartTime time.Time `json:"sale_start_time"`
+	SaleEndTime   time.Time `json:"sale_end_time"`
+	Description   string    `json:"description"`
+	MaxPerOrder   int       `json:"max_per_order"`
 }
 
 // PromoCode contains the details of a specific promotional code.
@@ -108,40 +228,162 @@ type PromoCode struct {
 	Currency   string  `json:"currency"`
 	Max        int     `json:"max_per_user"`
 	Remaining  int     `json:"remaining"`
+	EventID    int     `json:"-"` // Set by EventPromo, as the promo code applies to any ticket for the event
+
+	// ticketID is the ticket the promo code was looked up for, used by Refresh.
+	ticketID int
 }
 
 // Booking contains the resultant booking information.
 type Booking struct {
 	apiError
-	Event Event  `json:"event"`
-	Name  string `json:"user_full_name"`
-	PDF   string `json:"pdf"`
-	State int    `json:"state"`
+	ID                   int        `json:"id"`
+	Event                Event      `json:"event"`
+	Name                 string     `json:"user_full_name"`
+	PDF                  string     `json:"pdf"`
+	State                int        `json:"state"`
+	Amount               int        `json:"amount"`
+	Price                float64    `json:"price"`
+	BookingFee           float64    `json:"booking_fee"`
+	CancellationDeadline time.Time  `json:"cancellation_deadline"`
+	CreatedAt            time.Time  `json:"created_at"`
+	QRCodeURL            string     `json:"qr_code_url"`
+	CheckedIn            bool       `json:"checked_in"`
+	CheckInTime          *time.Time `json:"check_in_time"`
+	ExternalReference    string     `json:"external_reference"`
+	RefundPolicy         string     `json:"refund_policy"`
+	RefundDeadline       time.Time  `json:"refund_deadline"`
+	IdempotencyKey       string     `json:"purchase_key"`
+	ShareURL             string     `json:"share_url"`
+	TotalAmountPaid      float64    `json:"total_amount_paid"`
+	Currency             string     `json:"currency"`
 }
 
-// NewClient returns a FIXR client with the given email and password.
+// NewClient returns a FIXR client with the given email and the default configuration.
 func NewClient(email string) *Client {
-	return &Client{Email: email, httpClient: new(http.Client)}
+	c, _ := New(email)
+	return c
 }
 
-func (c *Client) get(addr string, auth bool, obj responseParams) error {
-	req, err := http.NewRequest("GET", addr, nil)
+// New returns a FIXR client with the given email, configured by opts.
+// An error will be returned if any of the options are invalid.
+func New(email string, opts ...ClientOption) (*Client, error) {
+	defaultHTTPClient := new(http.Client)
+	c := &Client{
+		Email:       email,
+		httpClient:  defaultHTTPClient,
+		baseURL:     DefaultBaseURL,
+		maxRetries:  defaultMaxRetries,
+		retryDelay:  defaultRetryDelay,
+		eventCache:  new(sync.Map),
+		flight:      new(flightGroup),
+		categories:  new(categoryCache),
+		recommended: new(recommendationCache),
+		stripeKey:   new(stripeKeyCache),
+		logger:      discardLogger{},
+
+		concurrency: defaultConcurrency,
+		rateLimit:   new(rateLimitState),
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, errors.Wrap(err, "error configuring client")
+		}
+	}
+	// Copy the http.Client so that per-client settings never leak into one supplied by the caller.
+	hc := *c.httpClient
+	if c.connPool != nil {
+		if c.httpClient != defaultHTTPClient {
+			return nil, errors.New("error configuring client: connection pool options cannot be combined with WithHTTPClient")
+		}
+		hc.Transport = c.connPool.transport()
+	}
+	if c.innerTransport != nil {
+		hc.Transport = c.innerTransport(hc.Transport)
+	}
+	if c.timeout > 0 {
+		hc.Timeout = c.timeout
+	}
+	for _, wrap := range c.transports {
+		hc.Transport = wrap(hc.Transport)
+	}
+	if c.maxRetries > 0 {
+		hc.Transport = &retryableTransport{base: hc.Transport, maxRetries: c.maxRetries, baseDelay: c.retryDelay}
+	}
+	c.httpClient = &hc
+	return c, nil
+}
+
+// Clone returns a copy of the client, such as for serving a different user with its own
+// AuthToken. The clone has its own http.Client, authentication details and interceptors,
+// but shares the original's configuration (logger, retry policy, base URL), transport
+// (and so its connection pool), circuit breaker and event cache.
+func (c *Client) Clone() *Client {
+	clone := *c
+	hc := *c.httpClient
+	clone.httpClient = &hc
+	if c.StripeUser != nil {
+		user := *c.StripeUser
+		user.Cards = append([]stripeCard(nil), c.StripeUser.Cards...)
+		clone.StripeUser = &user
+	}
+	clone.requestInterceptors = append([]RequestInterceptor(nil), c.requestInterceptors...)
+	clone.responseInterceptors = append([]ResponseInterceptor(nil), c.responseInterceptors...)
+	// Requests made for different users must not be deduplicated, and recommendations are per user.
+	clone.flight = new(flightGroup)
+	clone.recommended = new(recommendationCache)
+	return &clone
+}
+
+// NewClientFromToken returns a FIXR client which is already authenticated with authToken,
+// so Logon does not need to be called. The token can be checked with ValidateToken.
+func NewClientFromToken(email, authToken string, opts ...ClientOption) (*Client, error) {
+	c, err := New(email, opts...)
 	if err != nil {
-		return errors.New("error creating GET request")
+		return nil, err
 	}
-	return c.req(req, auth, obj)
+	c.AuthToken = authToken
+	return c, nil
+}
+
+func (c *Client) url(path string, a ...interface{}) string {
+	return c.baseURL + fmt.Sprintf(path, a...)
 }
 
-func (c *Client) post(addr string, data *bytes.Buffer, auth bool, obj responseParams) error {
-	req, err := http.NewRequest("POST", addr, data)
+func (c *Client) get(ctx context.Context, addr string, auth bool, obj responseParams) error {
+	return c.send(ctx, "GET", addr, nil, auth, obj)
+}
+
+func (c *Client) post(ctx context.Context, addr string, data *bytes.Buffer, auth bool, obj responseParams) error {
+	return c.send(ctx, "POST", addr, data, auth, obj)
+}
+
+func (c *Client) patch(ctx context.Context, addr string, data *bytes.Buffer, auth bool, obj responseParams) error {
+	return c.send(ctx, "PATCH", addr, data, auth, obj)
+}
+
+func (c *Client) del(ctx context.Context, addr string, auth bool, obj responseParams) error {
+	return c.send(ctx, "DELETE", addr, nil, auth, obj)
+}
+
+func (c *Client) send(ctx context.Context, method, addr string, data io.Reader, auth bool, obj responseParams) error {
+	req, err := http.NewRequestWithContext(ctx, method, addr, data)
 	if err != nil {
-		return errors.New("error creating POST request")
+		return errors.Errorf("error creating %s request", method)
 	}
 	return c.req(req, auth, obj)
 }
 
-func decodeJSONResponse(body io.ReadCloser, obj responseParams) error {
-	if err := json.NewDecoder(body).Decode(obj); err != nil {
+// decodeJSONResponse decodes the body of resp into obj. The pagination links given in resp's
+// Link header are also set on obj, if it is linkSetter.
+func decodeJSONResponse(resp *http.Response, obj responseParams) error {
+	if l, ok := obj.(linkSetter); ok {
+		defer l.setLinks(parseLinkHeader(resp.Header.Get("Link")))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(obj); err == io.EOF {
+		// Some endpoints respond without a body on success
+		return nil
+	} else if err != nil {
 		return errors.Wrap(err, "JSON decoding failed")
 	}
 	defer obj.clearError()
@@ -151,30 +393,168 @@ func decodeJSONResponse(body io.ReadCloser, obj responseParams) error {
 	return nil
 }
 
+// gzipBody decompresses a gzipped response body. The gzip.Reader is created on the
+// first Read, so that empty bodies are reported as io.EOF.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil {
+		zr, err := gzip.NewReader(b.body)
+		if err != nil {
+			return 0, err
+		}
+		b.zr = zr
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
+
 func (c *Client) req(req *http.Request, auth bool, obj responseParams) error {
-	req.Header.Set("User-Agent", UserAgent)
+	resp, err := c.do(req, auth)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeJSONResponse(resp, obj)
+}
+
+// do sets the FIXR headers on req and executes it. A typed error is returned
+// for unsuccessful responses; otherwise, the caller must close the response body.
+func (c *Client) do(req *http.Request, auth bool) (*http.Response, error) {
+	ua := UserAgent
+	if len(c.userAgent) > 0 {
+		ua = c.userAgent
+	}
+	req.Header.Set("User-Agent", ua)
+	// Setting Accept-Encoding disables transparent decompression by net/http,
+	// so gzipped responses are decoded below.
+	req.Header.Set("Accept-Encoding", "gzip")
 	if auth {
+		if err := c.requireAuth(); err != nil {
+			return nil, err
+		}
 		req.Header.Set("Authorization", fmt.Sprintf("Token %s", c.AuthToken))
 	}
 	if req.URL.String() == cardURL {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	} else {
-		req.Header.Set("Content-Type", "application/json")
+		if len(req.Header.Get("Content-Type")) == 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
 		// The following circumvents canonical formatting
 		req.Header["FIXR-Platform"] = []string{"web"}
-		req.Header["FIXR-Platform-Version"] = []string{FixrPlatformVer}
-		req.Header["FIXR-App-Version"] = []string{FixrVersion}
+		appVersion, platformVersion := fixrVersions()
+		req.Header["FIXR-Platform-Version"] = []string{platformVersion}
+		req.Header["FIXR-App-Version"] = []string{appVersion}
+		if len(c.currency) > 0 {
+			req.Header["FIXR-Currency"] = []string{c.currency}
+		}
+	}
+	if len(c.locale) > 0 {
+		req.Header.Set("Accept-Language", c.locale)
+	}
+	if key := idempotencyKey(req.Context()); len(key) > 0 {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+	if c.requestIDFunc != nil {
+		if id := c.requestIDFunc(); len(id) > 0 {
+			req.Header.Set(requestIDHeader, id)
+		}
+	}
+	for _, intercept := range c.requestInterceptors {
+		if err := intercept(req); err != nil {
+			return nil, errors.Wrap(err, "error intercepting request")
+		}
+	}
+	if c.breaker != nil {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
 	}
+	end := c.tracing.start(req, auth)
 	resp, err := c.httpClient.Do(req)
+	end(resp, err)
+	if c.breaker != nil {
+		if err != nil && req.Context().Err() != nil {
+			c.breaker.cancel()
+		} else {
+			c.breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+		}
+	}
 	if err != nil {
-		return errors.Wrap(err, "error executing request")
+		return nil, errors.Wrap(err, "error executing request")
 	}
-	defer resp.Body.Close()
-	return decodeJSONResponse(resp.Body, obj)
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		resp.Body = &gzipBody{body: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	for _, intercept := range c.responseInterceptors {
+		if err := intercept(resp); err != nil {
+			resp.Body.Close()
+			return nil, errors.Wrap(err, "error intercepting response")
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		var err error
+		if req.URL.String() == cardURL {
+			err = stripeStatusError(resp)
+		} else {
+			err = statusError(resp)
+		}
+		if rateErr, ok := err.(*RateLimitError); ok {
+			c.rateLimit.set(rateErr)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// onBaseHost reports whether u is on the same host as the client's base URL.
+func (c *Client) onBaseHost(u *url.URL) bool {
+	base, err := url.Parse(c.baseURL)
+	return err == nil && strings.EqualFold(u.Host, base.Host)
+}
+
+// download makes a GET request for addr, such as a file linked from a booking. The user's
+// auth token and the FIXR headers are only sent if addr is on the same host as the client's
+// base URL (see onBaseHost), rather than to whichever host it names. As with do, the caller
+// must close the response body.
+func (c *Client) download(ctx context.Context, addr string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", addr, nil)
+	if err != nil {
+		return nil, errors.New("error creating GET request")
+	}
+	if c.onBaseHost(req.URL) {
+		return c.do(req, true)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing request")
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, &HTTPError{statusCode: resp.StatusCode}
+	}
+	return resp, nil
 }
 
 // Logon authenticates the client with FIXR and returns an error if encountered.
 func (c *Client) Logon(pass string) error {
+	return c.LogonWithContext(context.Background(), pass)
+}
+
+// LogonWithContext is like Logon but uses ctx for the underlying HTTP request.
+func (c *Client) LogonWithContext(ctx context.Context, pass string) error {
 	pl := payload{
 		"email":    c.Email,
 		"password": pass,
@@ -183,66 +563,206 @@ func (c *Client) Logon(pass string) error {
 	if err != nil {
 		return err
 	}
-	if err := c.post(loginURL, data, false, c); err != nil {
+	if err := c.post(ctx, c.url(loginPath), data, false, c); err != nil {
 		return errors.Wrap(err, "error logging on")
 	}
 	return nil
 }
 
 // Event returns the event information for a given event ID (integer).
-// An error will be returned if one is encountered.
-func (c *Client) Event(id int) (*Event, error) {
-	event := Event{}
-	if err := c.get(fmt.Sprintf(eventURL, id), false, &event); err != nil {
+// If the API does not list the event's Tickets inline, or truncates them for events with many
+// ticket types, the first page of tickets (see EventTickets) is fetched in their place; use
+// AllTickets to get every ticket. An error will be returned if one is encountered.
+func (c *Client) Event(id int, opts ...EventOption) (*Event, error) {
+	return c.EventWithContext(context.Background(), id, opts...)
+}
+
+// EventWithContext is like Event but uses ctx for the underlying HTTP request.
+func (c *Client) EventWithContext(ctx context.Context, id int, opts ...EventOption) (*Event, error) {
+	if event, ok := c.cachedEvent(id); ok {
+		return event, nil
+	}
+	options := eventOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	ctx, cancel := withTimeout(ctx, options.timeout)
+	defer cancel()
+	// Concurrent lookups of the same event share a single request.
+	v, err := c.shared(ctx, "event:"+strconv.Itoa(id), func(ctx context.Context) (interface{}, error) {
+		event, err := c.fetchEvent(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		c.cacheEvent(event)
+		return event, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyEvent(v.(*Event)), nil
+}
+
+// shared calls fn once for concurrent calls with the same key, returning its result to each
+// (see flightGroup). fn is limited by the client's timeout (see WithTimeout).
+func (c *Client) shared(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return c.flight.do(ctx, key, func(ctx context.Context) (interface{}, error) {
+		ctx, cancel := withTimeout(ctx, c.timeout)
+		defer cancel()
+		return fn(ctx)
+	})
+}
+
+// eventResponse decodes an event, along with its total number of tickets, which is greater than
+// the number listed inline if they were truncated.
+type eventResponse struct {
+	Event
+	TicketCount *int `json:"ticket_count"`
+}
+
+// fetchEvent gets an event from the API, bypassing the cache. If the event's tickets were not
+// listed inline, or were truncated, the first page of them is fetched with EventTickets.
+func (c *Client) fetchEvent(ctx context.Context, id int) (*Event, error) {
+	resp := eventResponse{}
+	if err := c.get(ctx, c.url(eventPath, id), false, &resp); err != nil {
 		return nil, errors.Wrap(err, "error getting event")
 	}
+	event := resp.Event
+	if event.Tickets == nil || (resp.TicketCount != nil && *resp.TicketCount > len(event.Tickets)) {
+		page, err := c.EventTickets(ctx, id, 1, ticketPageSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting event")
+		}
+		event.Tickets = page.Items
+	}
 	return &event, nil
 }
 
 // Promo checks for the existence of a promotion code for a given ticket ID.
 // The returned *PromoCode can subsequently be passed to Book().
 // An error will be returned if one is encountered.
-func (c *Client) Promo(ticketID int, code string) (*PromoCode, error) {
-	promo := PromoCode{}
-	if err := c.get(fmt.Sprintf(promoURL, ticketID, code), true, &promo); err != nil {
-		return nil, errors.Wrap(err, "error getting promo code")
+func (c *Client) Promo(ticketID int, code string, opts ...PromoOption) (*PromoCode, error) {
+	return c.PromoWithContext(context.Background(), ticketID, code, opts...)
+}
+
+// PromoWithContext is like Promo but uses ctx for the underlying HTTP request.
+func (c *Client) PromoWithContext(ctx context.Context, ticketID int, code string, opts ...PromoOption) (*PromoCode, error) {
+	options := promoOptions{}
+	for _, opt := range opts {
+		opt(&options)
 	}
+	ctx, cancel := withTimeout(ctx, options.timeout)
+	defer cancel()
+	// Concurrent lookups of the same promo code share a single request.
+	v, err := c.shared(ctx, fmt.Sprintf("promo:%d:%s", ticketID, code), func(ctx context.Context) (interface{}, error) {
+		promo := PromoCode{ticketID: ticketID}
+		if err := c.get(ctx, c.url(promoPath, ticketID, code), true, &promo); err != nil {
+			return nil, errors.Wrap(err, "error getting promo code")
+		}
+		return promo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	promo := v.(PromoCode)
 	return &promo, nil
 }
 
+// checkTicket validates a purchase of amount tickets locally, without an API call.
+func checkTicket(ticket *Ticket, amount int) error {
+	if ticket.SoldOut {
+		return &SoldOutError{TicketID: ticket.ID}
+	}
+	if ticket.Expired {
+		return &ExpiredError{TicketID: ticket.ID}
+	}
+	if amount <= 0 {
+		return &ValidationError{Field: "amount", Message: "must be at least 1"}
+	}
+	if amount < ticket.Min {
+		return &ValidationError{Field: "amount", Message: fmt.Sprintf("must be at least the minimum (%d)", ticket.Min)}
+	}
+	if amount > ticket.Max {
+		return &ValidationError{Field: "amount", Message: fmt.Sprintf("cannot be more than the maximum (%d)", ticket.Max)}
+	}
+	if ticket.MaxPerOrder > 0 && amount > ticket.MaxPerOrder {
+		return &ValidationError{Field: "amount", Message: fmt.Sprintf("cannot be more than the maximum per order (%d)", ticket.MaxPerOrder)}
+	}
+	return nil
+}
+
 // Book books a ticket, given a *Ticket and an amout (with the option of a promo code).
+// Promo codes from Promo are sent as the booking's promo_code, and those from EventPromo
+// (which have an EventID) as its event_promo_code.
+// If the client's DryRun field is set, a synthetic booking is returned without making any API calls.
+// Before booking, the ticket is checked with an API call to ensure it is on sale
+// (see WithoutTicketRefresh); a *TicketNotYetValidError is returned if it is not.
 // The booking details and an error, if encountered, will be returned.
-func (c *Client) Book(ticket *Ticket, amount int, promo *PromoCode) (*Booking, error) {
-	fmt.Println(ticket)
+func (c *Client) Book(ticket *Ticket, amount int, promo *PromoCode, opts ...BookOption) (*Booking, error) {
+	return c.BookWithContext(context.Background(), ticket, amount, promo, opts...)
+}
+
+// BookWithContext is like Book but uses ctx for the underlying HTTP requests.
+func (c *Client) BookWithContext(ctx context.Context, ticket *Ticket, amount int, promo *PromoCode, opts ...BookOption) (*Booking, error) {
+	options := bookOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.ctx != nil {
+		ctx = options.ctx
+	}
+	ctx, cancel := withTimeout(ctx, options.timeout)
+	defer cancel()
+	c.logger.Debug("booking ticket", "ticket", ticket.ID, "amount", amount)
 	booking := Booking{}
 	pl := payload{
 		"ticket_id": ticket.ID,
 		"amount":    amount,
 	}
+	if err := checkTicket(ticket, amount); err != nil {
+		return nil, err
+	}
 	/* ticket.Invalid can change upon ticket release (i.e. is time dependent),
-	it should therefore be checked with an API call. */
-	for t, msg := range map[bool]string{
-		ticket.SoldOut: "ticket selection has sold out",
-		ticket.Expired: "ticket selection has expired"} {
-		if t {
-			return nil, errors.New(msg)
+	it should therefore be checked with an API call (except in dry-run mode). */
+	if options.skipRefresh || c.DryRun {
+		if ticket.Invalid {
+			return nil, &TicketNotYetValidError{TicketID: ticket.ID}
 		}
+	} else if err := c.checkTicketValid(ctx, ticket.ID); err != nil {
+		return nil, err
 	}
-	if amount > ticket.Max {
-		return nil, fmt.Errorf("cannot purchase more than the maximum (%d)", ticket.Max)
+	if c.DryRun {
+		return c.dryRunBooking(ticket, amount, promo), nil
 	}
-	if ticket.BookingFee+ticket.Price > 0 {
-		pl["purchase_key"] = genKey()
+	var err error
+	key := options.purchaseKey
+	if len(key) == 0 && ticket.BookingFee+ticket.Price > 0 {
+		if key, err = genKey(); err != nil {
+			return nil, err
+		}
+	}
+	if len(key) > 0 {
+		pl["purchase_key"] = key
+		// The purchase key prevents duplicate bookings, so the request can be retried.
+		ctx = withIdempotencyKey(ctx, key)
 	}
-	if promo != nil {
+	if promo != nil && promo.EventID != 0 {
+		pl["event_promo_code"] = promo.Code
+	} else if promo != nil {
 		pl["promo_code"] = promo.Code
 	}
 	data, err := jsonifyPayload(pl)
 	if err != nil {
 		return nil, err
 	}
-	if err := c.post(bookingURL, data, true, &booking); err != nil {
+	if err := c.post(ctx, c.url(bookingPath), data, true, &booking); err != nil {
+		if errorCode(err) == codeAlreadyBooked {
+			return nil, &AlreadyBookedError{*asAPIError(err)}
+		}
 		return nil, errors.Wrap(err, "error booking ticket")
 	}
+	if len(booking.IdempotencyKey) == 0 {
+		booking.IdempotencyKey = key
+	}
 	return &booking, nil
 }
@@ -0,0 +1,128 @@
+package fixr
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := New("test@example.com", append([]ClientOption{WithBaseURL(server.URL), WithMaxRetries(0)}, opts...)...)
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	return c
+}
+
+func TestEventWithBaseURL(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/event/1" {
+			t.Errorf("expected %s; got %s\n", "/event/1", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "test", "tickets": []}`)
+	})
+	e, err := c.Event(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "test" {
+		t.Errorf("expected %s; got %s\n", "test", e.Name)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	expected := "fixr-test"
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != expected {
+			t.Errorf("expected %s; got %s\n", expected, ua)
+		}
+		fmt.Fprint(w, `{}`)
+	}, WithUserAgent(expected))
+	if _, err := c.Event(1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusOK, `{}`))
+	c.AuthToken = "original"
+	c.UseRequestInterceptor(func(*http.Request) error { return nil })
+	clone := c.Clone()
+	clone.AuthToken = "clone"
+	clone.UseRequestInterceptor(func(*http.Request) error { return nil })
+	if c.AuthToken != "original" {
+		t.Errorf("expected %s; got %s\n", "original", c.AuthToken)
+	}
+	if len(c.requestInterceptors) != 1 || len(clone.requestInterceptors) != 2 {
+		t.Errorf("expected 1 and 2 interceptors; got %d and %d\n", len(c.requestInterceptors), len(clone.requestInterceptors))
+	}
+	if clone.httpClient == c.httpClient || clone.httpClient.Transport != c.httpClient.Transport {
+		t.Error("expected a new http.Client sharing the original's transport")
+	}
+	if clone.eventCache != c.eventCache || clone.baseURL != c.baseURL {
+		t.Error("expected the clone to share the event cache and base URL")
+	}
+}
+
+func TestUserAgentPerClient(t *testing.T) {
+	var received []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("User-Agent"))
+		fmt.Fprint(w, `{"tickets": []}`)
+	}
+	clients := []*Client{
+		newTestClient(t, handler, WithUserAgent("app-a/1.0")),
+		newTestClient(t, handler, WithUserAgent("app-b/2.0")),
+		newTestClient(t, handler),
+	}
+	for _, c := range clients {
+		if _, err := c.Event(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if expected := []string{"app-a/1.0", "app-b/2.0", UserAgent}; fmt.Sprint(received) != fmt.Sprint(expected) {
+		t.Errorf("expected %v; got %v\n", expected, received)
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	for _, opt := range []ClientOption{
+		WithHTTPClient(nil),
+		WithTimeout(-1),
+		WithBaseURL("fixr"),
+		WithMaxRetries(-1),
+	} {
+		if _, err := New("test@example.com", opt); err == nil {
+			t.Error("expected invalid option to fail")
+		}
+	}
+}
+
+func TestGzipResponse(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/featured_events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if ae := r.Header.Get("Accept-Encoding"); ae != "gzip" {
+			t.Errorf("expected %s; got %s\n", "gzip", ae)
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write(fixture)
+		zw.Close()
+	})
+	events, err := c.GetFeaturedEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 {
+		t.Error("expected featured events to be decoded")
+	}
+}
@@ -0,0 +1,38 @@
+package fixr
+
+import "strings"
+
+// DryRunState is the State of the synthetic bookings returned by Book in dry-run mode.
+const DryRunState = -1
+
+// WithDryRun puts the client in dry-run mode (see Client.DryRun): Book validates bookings
+// locally and returns a synthetic Booking, without making any API calls.
+func WithDryRun() ClientOption {
+	return func(c *Client) error {
+		c.DryRun = true
+		return nil
+	}
+}
+
+// EstimateBookingCost returns the total cost of booking amount tickets, including
+// booking fees and the discount given by promo (which may be nil).
+func (c *Client) EstimateBookingCost(ticket *Ticket, amount int, promo *PromoCode) float64 {
+	return ticket.EffectivePrice(promo) * float64(amount)
+}
+
+func (c *Client) dryRunBooking(ticket *Ticket, amount int, promo *PromoCode) *Booking {
+	price, fee := ticket.Price, ticket.BookingFee
+	if promo != nil {
+		price, fee = promo.Price, promo.BookingFee
+	}
+	return &Booking{
+		Name:       strings.TrimSpace(c.FirstName + " " + c.LastName),
+		State:      DryRunState,
+		Amount:     amount,
+		Price:      price,
+		BookingFee: fee,
+		Currency:   ticket.Currency,
+
+		TotalAmountPaid: (price + fee) * float64(amount),
+	}
+}
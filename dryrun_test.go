@@ -0,0 +1,42 @@
+package fixr
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type failingTransport struct {
+	t *testing.T
+}
+
+func (f failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.t.Errorf("unexpected request in dry-run mode: %s %s\n", req.Method, req.URL)
+	return nil, http.ErrNotSupported
+}
+
+func TestDryRun(t *testing.T) {
+	c, err := New("test@example.com", WithDryRun(), WithHTTPClient(&http.Client{Transport: failingTransport{t}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticket := &Ticket{ID: 1, Price: 10, BookingFee: 1, Max: 4}
+	b, err := c.Book(ticket, 2, &PromoCode{Code: "HALF", Price: 5, BookingFee: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.State != DryRunState || b.Amount != 2 || b.Price != 5 || b.BookingFee != 0.5 {
+		t.Errorf("unexpected booking: %+v\n", b)
+	}
+	if _, err := c.Book(ticket, 5, nil); err == nil {
+		t.Error("expected dry run to validate amount")
+	}
+	notYetValidErr := new(TicketNotYetValidError)
+	if _, err := c.Book(&Ticket{ID: 2, Max: 1, Invalid: true}, 1, nil); !errors.As(err, &notYetValidErr) {
+		t.Errorf("expected *TicketNotYetValidError; got %v\n", err)
+	}
+	if result, expected := c.EstimateBookingCost(ticket, 2, nil), 22.0; result != expected {
+		t.Errorf("expected %.2f; got %.2f\n", expected, result)
+	}
+}
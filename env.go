@@ -0,0 +1,74 @@
+package fixr
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The environment variables read by NewClientFromEnv.
+const (
+	envEmail      = "FIXR_EMAIL"
+	envPassword   = "FIXR_PASSWORD"
+	envToken      = "FIXR_TOKEN"
+	envBaseURL    = "FIXR_BASE_URL"
+	envTimeout    = "FIXR_TIMEOUT"
+	envMaxRetries = "FIXR_MAX_RETRIES"
+)
+
+// ValidateEnv returns the names of the required environment variables used by NewClientFromEnv
+// which are not set: FIXR_EMAIL, and either FIXR_PASSWORD or FIXR_TOKEN (both are returned if
+// neither is set).
+func ValidateEnv() []string {
+	var missing []string
+	if len(os.Getenv(envEmail)) == 0 {
+		missing = append(missing, envEmail)
+	}
+	if len(os.Getenv(envPassword)) == 0 && len(os.Getenv(envToken)) == 0 {
+		missing = append(missing, envPassword, envToken)
+	}
+	return missing
+}
+
+// NewClientFromEnv returns a client configured by environment variables. FIXR_EMAIL is required,
+// along with FIXR_TOKEN (an auth token) or FIXR_PASSWORD, in which case Logon is called.
+// FIXR_BASE_URL, FIXR_TIMEOUT (a duration such as "10s") and FIXR_MAX_RETRIES are optional.
+// An error listing any missing variables (see ValidateEnv) will be returned if the client
+// cannot be created.
+func NewClientFromEnv() (*Client, error) {
+	if missing := ValidateEnv(); len(missing) > 0 {
+		return nil, errors.Errorf("missing environment variables: %s", strings.Join(missing, ", "))
+	}
+	var opts []ClientOption
+	if baseURL := os.Getenv(envBaseURL); len(baseURL) > 0 {
+		opts = append(opts, WithBaseURL(baseURL))
+	}
+	if timeout := os.Getenv(envTimeout); len(timeout) > 0 {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", envTimeout)
+		}
+		opts = append(opts, WithTimeout(d))
+	}
+	if retries := os.Getenv(envMaxRetries); len(retries) > 0 {
+		n, err := strconv.Atoi(retries)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", envMaxRetries)
+		}
+		opts = append(opts, WithMaxRetries(n))
+	}
+	if token := os.Getenv(envToken); len(token) > 0 {
+		return NewClientFromToken(os.Getenv(envEmail), token, opts...)
+	}
+	c, err := New(os.Getenv(envEmail), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Logon(os.Getenv(envPassword)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,58 @@
+package fixr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidateEnv(t *testing.T) {
+	t.Setenv(envEmail, "")
+	t.Setenv(envPassword, "")
+	t.Setenv(envToken, "")
+	if missing := fmt.Sprint(ValidateEnv()); missing != "[FIXR_EMAIL FIXR_PASSWORD FIXR_TOKEN]" {
+		t.Errorf("expected %s; got %s\n", "[FIXR_EMAIL FIXR_PASSWORD FIXR_TOKEN]", missing)
+	}
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Error("expected missing variables to fail")
+	}
+	t.Setenv(envEmail, "test@example.com")
+	t.Setenv(envToken, "abc")
+	if missing := ValidateEnv(); len(missing) > 0 {
+		t.Errorf("expected no missing variables; got %v\n", missing)
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"auth_token": "abc"}`)
+	}))
+	defer server.Close()
+	t.Setenv(envEmail, "test@example.com")
+	t.Setenv(envPassword, "password")
+	t.Setenv(envToken, "")
+	t.Setenv(envBaseURL, server.URL)
+	t.Setenv(envTimeout, "10s")
+	t.Setenv(envMaxRetries, "0")
+	c, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.AuthToken != "abc" || c.baseURL != server.URL || c.httpClient.Timeout != 10*time.Second || c.maxRetries != 0 {
+		t.Errorf("unexpected client state: %+v\n", c)
+	}
+
+	t.Setenv(envToken, "token")
+	if c, err = NewClientFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if c.AuthToken != "token" {
+		t.Errorf("expected %s; got %s\n", "token", c.AuthToken)
+	}
+	t.Setenv(envTimeout, "ten seconds")
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Error("expected an invalid timeout to fail")
+	}
+}
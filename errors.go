@@ -0,0 +1,362 @@
+package fixr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// APIError is returned when the FIXR API responds with an unsuccessful HTTP status.
+// More specific errors (AuthError, NotFoundError, RateLimitError, MaintenanceError) embed it
+// but do not unwrap to it, so errors.As to *APIError only matches responses without a more
+// specific type; use errors.As with the specific type instead, or *HTTPError for the status.
+// (Within the package, errorCode reads the code of any of them.)
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// RequestID is the X-Request-ID of the failed request (see SetRequestIDFunc), if any.
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if len(msg) == 0 {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.StatusCode == 0 {
+		return msg
+	}
+	if len(e.Code) > 0 {
+		return fmt.Sprintf("%s (code: %s; status: %d)", msg, e.Code, e.StatusCode)
+	}
+	return fmt.Sprintf("%s (status: %d)", msg, e.StatusCode)
+}
+
+func (e *APIError) base() *APIError {
+	return e
+}
+
+// Unwrap returns the *HTTPError for the response, so that the status code of any error
+// built from an API response can be retrieved with errors.As.
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == 0 {
+		return nil
+	}
+	return &HTTPError{statusCode: e.StatusCode}
+}
+
+// HTTPError carries the HTTP status of an unsuccessful API response. It is wrapped by
+// APIError and the errors which embed it, regardless of whether the body could be decoded.
+type HTTPError struct {
+	statusCode int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d %s", e.statusCode, http.StatusText(e.statusCode))
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (e *HTTPError) StatusCode() int {
+	return e.statusCode
+}
+
+// AuthError is returned when the FIXR API rejects the client's credentials (HTTP 401 or 403).
+type AuthError struct {
+	APIError
+}
+
+// ForbiddenError is returned when the client is authenticated but may not access the
+// resource, such as another user's booking (HTTP 403). It unwraps to an *AuthError.
+type ForbiddenError struct {
+	AuthError
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return &e.AuthError
+}
+
+// errNotAuthenticated is returned (without making a request) when an authenticated
+// method is called before Logon.
+func errNotAuthenticated() error {
+	return &AuthError{APIError{Message: "client is not authenticated"}}
+}
+
+// NotFoundError is returned when the requested resource does not exist (HTTP 404).
+type NotFoundError struct {
+	APIError
+}
+
+// RateLimitError is returned when too many requests have been made (HTTP 429).
+// RetryAfter is the earliest time at which the request should be retried, if known.
+type RateLimitError struct {
+	APIError
+	RetryAfter time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter.IsZero() {
+		return e.APIError.Error()
+	}
+	return fmt.Sprintf("%s (retry after %s)", e.APIError.Error(), e.RetryAfter.Format(time.RFC1123))
+}
+
+// TooManyRequestsError is an alias of RateLimitError, returned when an action (such as
+// ResendBookingConfirmation) has been repeated too often.
+type TooManyRequestsError = RateLimitError
+
+// parseRetryAfter parses a Retry-After header given in either seconds or as an HTTP date.
+// The zero time is returned if the header is missing or invalid.
+func parseRetryAfter(header string) time.Time {
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Now().Add(time.Duration(secs) * time.Second)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// MaintenanceError is returned when the FIXR API is down for scheduled maintenance (HTTP 503).
+// EndTime is when the maintenance is expected to finish, if known (see WaitForMaintenance).
+type MaintenanceError struct {
+	APIError
+	EndTime time.Time
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.EndTime.IsZero() {
+		return e.APIError.Error()
+	}
+	return fmt.Sprintf("%s (maintenance ends %s)", e.APIError.Error(), e.EndTime.Format(time.RFC1123))
+}
+
+// APIVersionError is returned when the FIXR API rejects the client's FixrVersion as outdated
+// (see UpdateVersion and SetFixrVersion). Required is the version expected by the API, if known.
+type APIVersionError struct {
+	APIError
+	Required string
+	Actual   string
+}
+
+func (e *APIVersionError) Error() string {
+	if len(e.Required) == 0 {
+		return fmt.Sprintf("FIXR version %s is no longer supported", e.Actual)
+	}
+	return fmt.Sprintf("FIXR version %s is required (using %s)", e.Required, e.Actual)
+}
+
+// CancelNotAllowedError is returned when a booking cannot be cancelled because it has been checked in.
+type CancelNotAllowedError struct {
+	APIError
+}
+
+// TransferNotAllowedError is returned by TransferTicket when the event organiser has disabled transfers.
+type TransferNotAllowedError struct {
+	APIError
+}
+
+// AlreadyBookedError is returned by Book when its purchase key has already been used
+// for a successful booking (see WithPurchaseKey).
+type AlreadyBookedError struct {
+	APIError
+}
+
+// AlreadyOnWaitlistError is returned by WaitlistJoin when the user is already on the waitlist.
+type AlreadyOnWaitlistError struct {
+	APIError
+}
+
+// NotOnWaitlistError is returned by WaitlistLeave and GetWaitlistPosition when the user
+// is not on the waitlist.
+type NotOnWaitlistError struct {
+	APIError
+}
+
+// OAuthError is returned when a third-party identity token (such as from Sign in with Apple)
+// is rejected by FIXR as invalid or expired. Provider is the identity provider, e.g. "apple".
+type OAuthError struct {
+	APIError
+	Provider string
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("%s sign-in failed: %s", e.Provider, e.APIError.Error())
+}
+
+// PasswordMismatchError is returned by DeleteUserAccount when the password is incorrect.
+type PasswordMismatchError struct {
+	APIError
+}
+
+// RegistrationError is returned by RegisterUser when the email address is already in use.
+type RegistrationError struct {
+	APIError
+}
+
+// SoldOutError is returned by Book when a ticket has sold out.
+type SoldOutError struct {
+	TicketID int
+}
+
+func (e *SoldOutError) Error() string {
+	return fmt.Sprintf("ticket %d has sold out", e.TicketID)
+}
+
+// ExpiredError is returned by Book when a ticket is no longer on sale.
+type ExpiredError struct {
+	TicketID int
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("ticket %d has expired", e.TicketID)
+}
+
+// TicketNotYetValidError is returned by Book when a ticket has not yet been released.
+type TicketNotYetValidError struct {
+	TicketID  int
+	ValidFrom time.Time
+}
+
+func (e *TicketNotYetValidError) Error() string {
+	if e.ValidFrom.IsZero() {
+		return fmt.Sprintf("ticket %d is not yet valid", e.TicketID)
+	}
+	return fmt.Sprintf("ticket %d is not valid until %s", e.TicketID, e.ValidFrom.Format(time.RFC1123))
+}
+
+// Warning is implemented by non-fatal errors, returned by methods which otherwise succeeded.
+type Warning interface {
+	error
+	IsWarning() bool
+}
+
+// IsWarning reports whether err is (or wraps) a Warning, and so can be ignored if desired.
+func IsWarning(err error) bool {
+	var w Warning
+	return errors.As(err, &w) && w.IsWarning()
+}
+
+// PriceChange describes a change in the TotalCost of a ticket.
+type PriceChange struct {
+	TicketID int
+	OldPrice float64
+	NewPrice float64
+}
+
+// StaleDataWarning is returned by Event.RefreshFromAPI when the price of any of the event's
+// tickets has changed, so that users can be notified before booking. It is a Warning.
+type StaleDataWarning struct {
+	Changes []PriceChange
+}
+
+func (w *StaleDataWarning) Error() string {
+	return fmt.Sprintf("the price of %d ticket(s) has changed", len(w.Changes))
+}
+
+// IsWarning returns true.
+func (w *StaleDataWarning) IsWarning() bool {
+	return true
+}
+
+// ValidationError is returned when a value is rejected, either locally or by the API.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// CardValidationError is returned by TokenizeCard when a card detail is invalid.
+// Field is the detail at fault ("number", "exp_month", "exp_year" or "cvc").
+type CardValidationError struct {
+	Field   string
+	Message string
+	// RequestID is the X-Request-ID of the request rejected by Stripe, if any.
+	RequestID string
+}
+
+func (e *CardValidationError) Error() string {
+	return fmt.Sprintf("invalid card %s: %s", e.Field, e.Message)
+}
+
+const (
+	codeBookingCheckedIn = "booking_checked_in"
+	codeEmailInUse       = "email_in_use"
+	codeWeakPassword     = "password_too_weak"
+	codeTransferDisabled = "transfer_disabled"
+	codeAlreadyBooked    = "purchase_key_used"
+	codeSharingDisabled  = "sharing_disabled"
+	codeOnWaitlist       = "already_on_waitlist"
+	codeNotOnWaitlist    = "not_on_waitlist"
+	codePasswordMismatch = "password_mismatch"
+	codeNoBookingHistory = "no_booking_history"
+)
+
+// asAPIError returns the *APIError embedded by whichever typed error (see APIError) err is
+// or wraps, or nil.
+func asAPIError(err error) *APIError {
+	var e interface{ base() *APIError }
+	if errors.As(err, &e) {
+		return e.base()
+	}
+	return nil
+}
+
+// errorCode returns the FIXR error code carried by err (or an empty string), whichever
+// typed error carries it.
+func errorCode(err error) string {
+	if apiErr := asAPIError(err); apiErr != nil {
+		return apiErr.Code
+	}
+	return ""
+}
+
+type errorBody struct {
+	Message            string     `json:"message"`
+	Detail             string     `json:"detail"`
+	Code               string     `json:"code"`
+	MaintenanceEndTime *time.Time `json:"maintenance_end_time"`
+	AppVersion         string     `json:"app_version"`
+	UpgradeRequired    bool       `json:"upgrade_required"`
+}
+
+// statusError builds the typed error corresponding to an unsuccessful response.
+func statusError(resp *http.Response) error {
+	body := errorBody{}
+	// The body is informational only; an undecodable body still yields a typed error.
+	json.NewDecoder(resp.Body).Decode(&body)
+	apiErr := APIError{StatusCode: resp.StatusCode, Code: body.Code, Message: body.Message, RequestID: requestID(resp)}
+	if len(apiErr.Message) == 0 {
+		apiErr.Message = body.Detail
+	}
+	if len(body.AppVersion) > 0 || body.UpgradeRequired {
+		versionErr := &APIVersionError{APIError: apiErr, Required: body.AppVersion}
+		// The header is set without canonical formatting (see Client.do).
+		if resp.Request != nil && len(resp.Request.Header["FIXR-App-Version"]) > 0 {
+			versionErr.Actual = resp.Request.Header["FIXR-App-Version"][0]
+		}
+		return versionErr
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return &AuthError{apiErr}
+	case http.StatusForbidden:
+		return &ForbiddenError{AuthError{apiErr}}
+	case http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case http.StatusTooManyRequests:
+		return &RateLimitError{APIError: apiErr, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	case http.StatusServiceUnavailable:
+		if body.MaintenanceEndTime != nil {
+			return &MaintenanceError{APIError: apiErr, EndTime: *body.MaintenanceEndTime}
+		}
+	}
+	return &apiErr
+}
@@ -0,0 +1,100 @@
+package fixr
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func statusHandler(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestAuthError(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusUnauthorized, `{"detail": "Invalid token."}`))
+	_, err := c.Event(1)
+	authErr := new(AuthError)
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected *AuthError; got %T\n", errors.Cause(err))
+	}
+	if authErr.Message != "Invalid token." {
+		t.Errorf("expected %s; got %s\n", "Invalid token.", authErr.Message)
+	}
+}
+
+func TestNotFoundError(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusNotFound, `{"detail": "Not found."}`))
+	_, err := c.Event(1)
+	notFoundErr := new(NotFoundError)
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected *NotFoundError; got %T\n", errors.Cause(err))
+	}
+	if notFoundErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected %d; got %d\n", http.StatusNotFound, notFoundErr.StatusCode)
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusTooManyRequests, ``))
+	_, err := c.Event(1)
+	rateErr := new(RateLimitError)
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected *RateLimitError; got %T\n", errors.Cause(err))
+	}
+	if wait := time.Until(rateErr.RetryAfter); wait <= 0 || wait > 30*time.Second {
+		t.Errorf("expected RetryAfter within 30s; got %v\n", wait)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusInternalServerError, `{"message": "oops", "code": "E1"}`))
+	_, err := c.Event(1)
+	apiErr := new(APIError)
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError; got %T\n", errors.Cause(err))
+	}
+	if apiErr.Code != "E1" || apiErr.Message != "oops" {
+		t.Errorf("expected %s/%s; got %s/%s\n", "E1", "oops", apiErr.Code, apiErr.Message)
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusInternalServerError} {
+		c := newTestClient(t, statusHandler(status, `not JSON`))
+		c.AuthToken = "abc"
+		for _, call := range []func() error{
+			func() error { _, err := c.Event(1); return err },
+			func() error { _, err := c.Promo(1, "CODE"); return err },
+			func() error { _, err := c.Book(&Ticket{ID: 1, Max: 1}, 1, nil, WithoutTicketRefresh()); return err },
+			func() error { return c.Logon("password") },
+		} {
+			httpErr := new(HTTPError)
+			if err := call(); !errors.As(err, &httpErr) {
+				t.Errorf("expected *HTTPError; got %v\n", err)
+			} else if httpErr.StatusCode() != status {
+				t.Errorf("expected %d; got %d\n", status, httpErr.StatusCode())
+			}
+		}
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusTooManyRequests} {
+		c := newTestClient(t, statusHandler(status, `{"message": "failed", "code": "some_code"}`))
+		_, err := c.Event(1)
+		if code := errorCode(err); code != "some_code" {
+			t.Errorf("expected %s; got %s (status: %d)\n", "some_code", code, status)
+		}
+		// Only errors without a more specific type are an *APIError.
+		apiErr := new(APIError)
+		if errors.As(err, &apiErr) != (status == http.StatusBadRequest) {
+			t.Errorf("unexpected *APIError match for status %d: %v\n", status, err)
+		}
+	}
+}
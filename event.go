@@ -0,0 +1,347 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EventOption configures a single call to Event.
+type EventOption func(*eventOptions)
+
+type eventOptions struct {
+	timeout time.Duration
+}
+
+// WithEventTimeout limits the time taken to fetch the event to d.
+func WithEventTimeout(d time.Duration) EventOption {
+	return func(o *eventOptions) {
+		o.timeout = d
+	}
+}
+
+// IsUpcoming reports whether the event has yet to start.
+func (e *Event) IsUpcoming() bool {
+	return time.Now().Before(e.StartTime)
+}
+
+// IsOngoing reports whether the event has started but not yet finished.
+func (e *Event) IsOngoing() bool {
+	now := time.Now()
+	return !now.Before(e.StartTime) && now.Before(e.EndTime)
+}
+
+// IsSoldOut reports whether the event has tickets and all of them have sold out.
+func (e *Event) IsSoldOut() bool {
+	return len(e.Tickets) > 0 && e.allTickets(func(t Ticket) bool { return t.SoldOut })
+}
+
+// IsExpired reports whether the event has tickets and all of them are no longer on sale.
+func (e *Event) IsExpired() bool {
+	return len(e.Tickets) > 0 && e.allTickets(func(t Ticket) bool { return t.Expired })
+}
+
+// IsOnSaleNow reports whether any of the event's tickets can currently be purchased.
+// It is equivalent to HasAvailableTickets.
+func (e *Event) IsOnSaleNow() bool {
+	return e.HasAvailableTickets()
+}
+
+func (e *Event) allTickets(fn func(Ticket) bool) bool {
+	for _, t := range e.Tickets {
+		if !fn(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAvailableTickets reports whether any of the event's tickets can currently be purchased.
+func (e *Event) HasAvailableTickets() bool {
+	_, ok := e.CheapestTicket()
+	return ok
+}
+
+// CheapestTicket returns the available ticket with the lowest TotalCost,
+// or false if none of the event's tickets are available.
+func (e *Event) CheapestTicket() (*Ticket, bool) {
+	var cheapest *Ticket
+	for i := range e.Tickets {
+		t := &e.Tickets[i]
+		if t.IsAvailable() && (cheapest == nil || t.TotalCost() < cheapest.TotalCost()) {
+			cheapest = t
+		}
+	}
+	return cheapest, cheapest != nil
+}
+
+// FreeTickets returns the event's tickets which cost nothing, including the booking fee.
+func (e *Event) FreeTickets() []Ticket {
+	var free []Ticket
+	for _, t := range e.Tickets {
+		if t.TotalCost() == 0 {
+			free = append(free, t)
+		}
+	}
+	return free
+}
+
+// FormattedDate returns the event's start time in loc (UTC if nil), formatted with
+// layout (time.RFC1123 if empty).
+func (e *Event) FormattedDate(loc *time.Location, layout string) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if len(layout) == 0 {
+		layout = time.RFC1123
+	}
+	return e.StartTime.In(loc).Format(layout)
+}
+
+// findTicket returns the ticket with the given ID from tickets.
+func findTicket(tickets []Ticket, id int) (*Ticket, bool) {
+	for i := range tickets {
+		if tickets[i].ID == id {
+			return &tickets[i], true
+		}
+	}
+	return nil, false
+}
+
+// RefreshTickets fetches the event again and updates the availability, price and booking fee
+// of its tickets in place, so that callers holding a *Ticket from e.Tickets see the new values.
+// Tickets which have been added to the event are appended to e.Tickets.
+func (e *Event) RefreshTickets(ctx context.Context, c *Client) error {
+	fresh, err := c.fetchEvent(ctx, e.ID)
+	if err != nil {
+		return errors.Wrap(err, "error refreshing tickets")
+	}
+	// Every existing ticket is updated before any are appended, as appending may reallocate
+	// e.Tickets, after which updates would no longer reach the callers' *Ticket.
+	var added []Ticket
+	for _, f := range fresh.Tickets {
+		t, ok := findTicket(e.Tickets, f.ID)
+		if !ok {
+			added = append(added, f)
+			continue
+		}
+		t.SoldOut, t.Expired, t.Invalid = f.SoldOut, f.Expired, f.Invalid
+		t.Price, t.BookingFee = f.Price, f.BookingFee
+	}
+	e.Tickets = append(e.Tickets, added...)
+	return nil
+}
+
+// RefreshFromAPI fetches the event again and updates every field of e with the new details.
+// Unlike RefreshTickets, e.Tickets is replaced, so any *Ticket taken from it is not updated.
+// If the TotalCost of any ticket has changed, a *StaleDataWarning (see IsWarning) listing the
+// changes is returned after e has been updated. RefreshFromAPI is not safe for concurrent use:
+// callers sharing e between goroutines must synchronise access to it themselves.
+func (e *Event) RefreshFromAPI(ctx context.Context, c *Client) error {
+	fresh, err := c.fetchEvent(ctx, e.ID)
+	if err != nil {
+		return errors.Wrap(err, "error refreshing event")
+	}
+	c.cacheEvent(fresh)
+	var changes []PriceChange
+	for _, f := range fresh.Tickets {
+		if t, ok := findTicket(e.Tickets, f.ID); ok && !moneyEqual(t.TotalCost(), f.TotalCost()) {
+			changes = append(changes, PriceChange{TicketID: f.ID, OldPrice: t.TotalCost(), NewPrice: f.TotalCost()})
+		}
+	}
+	*e = *fresh
+	if len(changes) > 0 {
+		return &StaleDataWarning{Changes: changes}
+	}
+	return nil
+}
+
+// GetEventBySlug returns the event with the given URL slug (e.g. "my-event" for
+// fixr.co/event/my-event). If the slug cannot be looked up directly, the first search
+// result with the same Slug is returned. A *NotFoundError will be returned if no event matches.
+func (c *Client) GetEventBySlug(ctx context.Context, slug string) (*Event, error) {
+	event := Event{}
+	err := c.get(ctx, c.url(slugPath, url.PathEscape(slug)), false, &event)
+	if err == nil {
+		return &event, nil
+	}
+	if notFound := new(NotFoundError); !errors.As(err, &notFound) {
+		return nil, errors.Wrap(err, "error getting event")
+	}
+	list, err := c.SearchEventsWithContext(ctx, slug, EventFilter{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting event")
+	}
+	for _, event := range list.Items {
+		if event.Slug == slug {
+			return &event, nil
+		}
+	}
+	return nil, &NotFoundError{APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("event %q not found", slug)}}
+}
+
+// maxEventConcurrency is the maximum number of events fetched at once by GetMultipleEvents.
+const maxEventConcurrency = 10
+
+// GetMultipleEvents fetches the events with the given IDs in parallel, returning them in
+// the same order as ids. The errors have the same length as ids, with nil entries for
+// the events which were fetched successfully.
+func (c *Client) GetMultipleEvents(ctx context.Context, ids []int) ([]*Event, []error) {
+	events, errs := make([]*Event, len(ids)), make([]error, len(ids))
+	parallel(len(ids), maxEventConcurrency, func(i int) {
+		events[i], errs[i] = c.EventWithContext(ctx, ids[i])
+	})
+	return events, errs
+}
+
+// defaultBatchSize is the maximum number of events fetched per request by GetEventsBatch.
+const defaultBatchSize = 50
+
+// BulkEventOption configures a single call to GetEventsBatch.
+type BulkEventOption func(*bulkEventOptions)
+
+type bulkEventOptions struct {
+	batchSize int
+}
+
+// WithBatchSize limits the number of events fetched per request to n (50 by default).
+func WithBatchSize(n int) BulkEventOption {
+	return func(o *bulkEventOptions) {
+		if n > 0 {
+			o.batchSize = n
+		}
+	}
+}
+
+// eventBatch decodes the batch endpoint's response, which maps event IDs to events.
+type eventBatch map[int]*Event
+
+func (eventBatch) error() error { return nil }
+
+func (eventBatch) clearError() {}
+
+// GetEventsBatch fetches the events with the given IDs using FIXR's batch endpoint, splitting
+// ids into requests of up to 50 events (see WithBatchSize). If the batch endpoint does not exist
+// (it responds 404 or 405 without an API error code), the remaining events are fetched
+// individually with GetMultipleEvents; any other failed batch request, including a 404 with an
+// error code, sets the error for each of the batch's events. As with GetMultipleEvents, the
+// events and errors are returned in the same order as ids; events missing from a batch
+// response have a *NotFoundError.
+func (c *Client) GetEventsBatch(ctx context.Context, ids []int, opts ...BulkEventOption) ([]*Event, []error) {
+	options := bulkEventOptions{batchSize: defaultBatchSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	events, errs := make([]*Event, len(ids)), make([]error, len(ids))
+	for start := 0; start < len(ids); start += options.batchSize {
+		end := start + options.batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch, err := c.fetchEventBatch(ctx, ids[start:end])
+		if batchUnavailable(err) {
+			rest, restErrs := c.GetMultipleEvents(ctx, ids[start:])
+			copy(events[start:], rest)
+			copy(errs[start:], restErrs)
+			break
+		}
+		for i := start; i < end; i++ {
+			if err != nil {
+				errs[i] = err
+			} else if event := batch[ids[i]]; event != nil {
+				c.cacheEvent(event)
+				events[i] = event
+			} else {
+				errs[i] = &NotFoundError{APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("event %d not found", ids[i])}}
+			}
+		}
+	}
+	return events, errs
+}
+
+// batchUnavailable reports whether err shows that the batch endpoint itself is missing, rather
+// than the API rejecting the request, which it does with an error code.
+func batchUnavailable(err error) bool {
+	e := asAPIError(err)
+	if e == nil || len(e.Code) > 0 {
+		return false
+	}
+	return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusMethodNotAllowed
+}
+
+func (c *Client) fetchEventBatch(ctx context.Context, ids []int) (eventBatch, error) {
+	data := new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(ids); err != nil {
+		return nil, errors.Wrap(err, "error encoding event IDs")
+	}
+	batch := eventBatch{}
+	if err := c.post(ctx, c.url(batchPath), data, false, &batch); err != nil {
+		return nil, errors.Wrap(err, "error getting events")
+	}
+	return batch, nil
+}
+
+// ticketPageSize is the number of tickets fetched per page by AllTickets.
+const ticketPageSize = 50
+
+// TicketPage contains a single page of an event's tickets.
+type TicketPage struct {
+	Items      []Ticket
+	Page       int
+	TotalPages int
+	TotalCount int
+}
+
+type ticketPage struct {
+	apiError
+	Items []Ticket `json:"results"`
+	Count int      `json:"count"`
+	Next  *string  `json:"next"`
+}
+
+// EventTickets returns the given page (starting at 1) of the tickets for the event with the
+// given ID. Unlike Event, which includes at most the first page of tickets for events with many
+// ticket types, every ticket can be fetched page by page.
+func (c *Client) EventTickets(ctx context.Context, eventID, page, pageSize int) (*TicketPage, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, errors.New("page and page size must be positive")
+	}
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+	resp := ticketPage{}
+	if err := c.get(ctx, c.url(ticketsPath, eventID)+"?"+query.Encode(), false, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting tickets")
+	}
+	totalPages := (resp.Count + pageSize - 1) / pageSize
+	if totalPages < page {
+		totalPages = page
+	}
+	if resp.Next != nil && totalPages == page {
+		totalPages++
+	}
+	return &TicketPage{Items: resp.Items, Page: page, TotalPages: totalPages, TotalCount: resp.Count}, nil
+}
+
+// AllTickets returns every ticket for the event with the given ID, fetching each page in turn.
+func (c *Client) AllTickets(ctx context.Context, eventID int) ([]Ticket, error) {
+	var tickets []Ticket
+	it := newPageIterator(func(ctx context.Context, page int) ([]Ticket, bool, error) {
+		resp, err := c.EventTickets(ctx, eventID, page, ticketPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Items, resp.Page < resp.TotalPages, nil
+	})
+	for it.Next(ctx) {
+		tickets = append(tickets, it.Value()...)
+	}
+	return tickets, it.Err()
+}
@@ -0,0 +1,393 @@
+package fixr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestEventTimes(t *testing.T) {
+	event := Event{}
+	if err := json.Unmarshal([]byte(`{"start_time": "2017-06-01T20:00:00Z", "end_time": "2017-06-02T03:00:00+01:00"}`), &event); err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(2017, 6, 1, 20, 0, 0, 0, time.UTC); !event.StartTime.Equal(expected) {
+		t.Errorf("expected %v; got %v\n", expected, event.StartTime)
+	}
+	if expected := time.Date(2017, 6, 2, 2, 0, 0, 0, time.UTC); !event.EndTime.Equal(expected) {
+		t.Errorf("expected %v; got %v\n", expected, event.EndTime)
+	}
+}
+
+func TestEventIsUpcomingOngoing(t *testing.T) {
+	now := time.Now()
+	for _, test := range []struct {
+		start, end        time.Time
+		upcoming, ongoing bool
+	}{
+		{now.Add(time.Hour), now.Add(2 * time.Hour), true, false},
+		{now.Add(-time.Hour), now.Add(time.Hour), false, true},
+		{now.Add(-2 * time.Hour), now.Add(-time.Hour), false, false},
+	} {
+		e := Event{StartTime: test.start, EndTime: test.end}
+		if e.IsUpcoming() != test.upcoming {
+			t.Errorf("expected IsUpcoming %t; got %t\n", test.upcoming, e.IsUpcoming())
+		}
+		if e.IsOngoing() != test.ongoing {
+			t.Errorf("expected IsOngoing %t; got %t\n", test.ongoing, e.IsOngoing())
+		}
+	}
+}
+
+func TestRefreshTickets(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "tickets": [{"id": 1, "sold_out": true, "price": 12}, {"id": 2, "name": "New"}]}`)
+	})
+	e := &Event{ID: 1, Tickets: []Ticket{{ID: 1, Name: "Old", Price: 10}}}
+	held := &e.Tickets[0]
+	if err := e.RefreshTickets(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if !held.SoldOut || held.Price != 12 || held.Name != "Old" {
+		t.Errorf("expected held ticket to be updated; got %+v\n", held)
+	}
+	if len(e.Tickets) != 2 || e.Tickets[1].Name != "New" {
+		t.Errorf("expected new ticket to be appended; got %+v\n", e.Tickets)
+	}
+}
+
+func TestRefreshTicketsNewTicketFirst(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "tickets": [{"id": 3, "name": "New"}, {"id": 1, "price": 12}, {"id": 2, "sold_out": true}]}`)
+	})
+	// A full slice, so that appending the new ticket reallocates it.
+	e := &Event{ID: 1, Tickets: []Ticket{{ID: 1, Price: 10}, {ID: 2}}}
+	first, second := &e.Tickets[0], &e.Tickets[1]
+	if err := e.RefreshTickets(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if first.Price != 12 || !second.SoldOut {
+		t.Errorf("expected held tickets to be updated; got %+v and %+v\n", first, second)
+	}
+	if len(e.Tickets) != 3 || e.Tickets[2].Name != "New" || e.Tickets[0].Price != 12 {
+		t.Errorf("expected new ticket to be appended; got %+v\n", e.Tickets)
+	}
+}
+
+func TestRefreshFromAPI(t *testing.T) {
+	price := 10
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": 1, "name": "Renamed", "tickets": [{"id": 1, "price": %d, "booking_fee": 1}]}`, price)
+	})
+	e := &Event{ID: 1, Name: "Original", Tickets: []Ticket{{ID: 1, Price: 10, BookingFee: 1}}}
+	if err := e.RefreshFromAPI(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "Renamed" {
+		t.Errorf("expected %s; got %s\n", "Renamed", e.Name)
+	}
+	price = 12
+	err := e.RefreshFromAPI(context.Background(), c)
+	stale := new(StaleDataWarning)
+	if !errors.As(err, &stale) || !IsWarning(err) {
+		t.Fatalf("expected *StaleDataWarning; got %v\n", err)
+	}
+	if expected := []PriceChange{{TicketID: 1, OldPrice: 11, NewPrice: 13}}; fmt.Sprint(stale.Changes) != fmt.Sprint(expected) {
+		t.Errorf("expected %v; got %v\n", expected, stale.Changes)
+	}
+	if e.Tickets[0].Price != 12 {
+		t.Errorf("expected %d; got %.2f\n", 12, e.Tickets[0].Price)
+	}
+	if IsWarning(errors.New("not a warning")) {
+		t.Error("expected a plain error not to be a warning")
+	}
+}
+
+func TestGetMultipleEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/event/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"id": %s, "tickets": []}`, r.URL.Path[len("/event/"):])
+	})
+	events, errs := c.GetMultipleEvents(context.Background(), []int{1, 2, 3})
+	for i, id := range []int{1, 0, 3} {
+		if id == 0 {
+			if errs[i] == nil {
+				t.Errorf("expected error for event %d\n", i+1)
+			}
+			continue
+		}
+		if errs[i] != nil || events[i].ID != id {
+			t.Errorf("expected event %d; got %v (%v)\n", id, events[i], errs[i])
+		}
+	}
+}
+
+func TestEventFormattedDate(t *testing.T) {
+	event := Event{StartTime: time.Date(2017, 6, 1, 23, 30, 0, 0, time.UTC)}
+	if result, expected := event.FormattedDate(nil, ""), "Thu, 01 Jun 2017 23:30:00 UTC"; result != expected {
+		t.Errorf("expected %s; got %s\n", expected, result)
+	}
+	loc := time.FixedZone("CEST", 2*60*60)
+	if result, expected := event.FormattedDate(loc, "2006-01-02 15:04"), "2017-06-02 01:30"; result != expected {
+		t.Errorf("expected %s; got %s\n", expected, result)
+	}
+}
+
+func TestEventTicketHelpers(t *testing.T) {
+	event := Event{Tickets: []Ticket{
+		{ID: 1, Price: 5, SoldOut: true},
+		{ID: 2, Price: 10, BookingFee: 1},
+		{ID: 3, Price: 8, BookingFee: 1},
+		{ID: 4, Expired: true},
+	}}
+	if !event.HasAvailableTickets() {
+		t.Error("expected event to have available tickets")
+	}
+	if ticket, ok := event.CheapestTicket(); !ok || ticket.ID != 3 {
+		t.Errorf("expected ticket %d; got %v\n", 3, ticket)
+	}
+	if free := event.FreeTickets(); len(free) != 1 || free[0].ID != 4 {
+		t.Errorf("expected ticket %d; got %v\n", 4, free)
+	}
+	event.Tickets[1].SoldOut, event.Tickets[2].SoldOut = true, true
+	if _, ok := event.CheapestTicket(); ok || event.HasAvailableTickets() {
+		t.Error("expected no available tickets")
+	}
+}
+
+func TestEventSaleState(t *testing.T) {
+	for _, test := range []struct {
+		tickets                     []Ticket
+		soldOut, expired, onSaleNow bool
+	}{
+		{nil, false, false, false},
+		{[]Ticket{{SoldOut: true}, {SoldOut: true}}, true, false, false},
+		{[]Ticket{{Expired: true}, {Expired: true, SoldOut: true}}, false, true, false},
+		{[]Ticket{{SoldOut: true}, {}}, false, false, true},
+	} {
+		event := Event{Tickets: test.tickets}
+		if event.IsSoldOut() != test.soldOut || event.IsExpired() != test.expired || event.IsOnSaleNow() != test.onSaleNow {
+			t.Errorf("expected %t/%t/%t; got %t/%t/%t (%+v)\n", test.soldOut, test.expired, test.onSaleNow,
+				event.IsSoldOut(), event.IsExpired(), event.IsOnSaleNow(), test.tickets)
+		}
+	}
+}
+
+func TestEventFixture(t *testing.T) {
+	c := newTestClient(t, serveFile(t, "testdata/event.json"))
+	event, err := c.Event(141151926)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		field            string
+		result, expected interface{}
+	}{
+		{"Description", event.Description, "The biggest night of the summer, with three stages and a silent disco."},
+		{"MinAge", event.MinAge, 18},
+		{"ImageURL", event.ImageURL, "https://fixr-cdn.fixr.co/images/event/summer-ball-2017.jpg"},
+		{"Website", event.Website, "https://summerball.example.com"},
+		{"Tickets[0].Description", event.Tickets[0].Description, "General admission."},
+		{"Tickets[0].MaxPerOrder", event.Tickets[0].MaxPerOrder, 4},
+		{"Tickets[0].SaleEndTime", event.Tickets[0].SaleEndTime, time.Date(2017, 6, 16, 18, 0, 0, 0, time.UTC)},
+	} {
+		if test.result != test.expected {
+			t.Errorf("expected %s %v; got %v\n", test.field, test.expected, test.result)
+		}
+	}
+}
+
+func TestEventTicketsSinglePage(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/event/141151926/tickets" {
+			fmt.Fprint(w, `{"count": 1, "next": null, "results": [{"id": 391220, "name": "Standard Entry", "price": 45.0}]}`)
+			return
+		}
+		serveFile(t, "testdata/event.json")(w, r)
+	})
+	event, err := c.Event(141151926)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := c.EventTickets(context.Background(), event.ID, 1, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Page != 1 || page.TotalPages != 1 || page.TotalCount != 1 {
+		t.Errorf("expected page 1 of 1; got %+v\n", page)
+	}
+	all, err := c.AllTickets(context.Background(), event.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tickets := range [][]Ticket{page.Items, all} {
+		if len(tickets) != len(event.Tickets) || tickets[0].ID != event.Tickets[0].ID || tickets[0].Price != event.Tickets[0].Price {
+			t.Errorf("expected %v; got %v\n", event.Tickets, tickets)
+		}
+	}
+}
+
+func TestAllTickets(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"count": 51, "next": "/event/1/tickets?page=2", "results": [{"id": 1}]}`)
+		default:
+			fmt.Fprint(w, `{"count": 51, "next": null, "results": [{"id": 2}]}`)
+		}
+	})
+	tickets, err := c.AllTickets(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 2 || tickets[1].ID != 2 {
+		t.Errorf("expected 2 tickets; got %v\n", tickets)
+	}
+}
+
+func TestEventTicketsMultiPage(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/event/1":
+			fmt.Fprint(w, `{"id": 1, "ticket_count": 3, "tickets": [{"id": 1}]}`)
+		case "/event/2":
+			fmt.Fprint(w, `{"id": 2}`)
+		case "/event/3":
+			fmt.Fprint(w, `{"id": 3, "ticket_count": 1, "tickets": [{"id": 1}]}`)
+		default:
+			if r.URL.Query().Get("page") != "1" {
+				fmt.Fprint(w, `{"count": 3, "next": null, "results": [{"id": 3}]}`)
+				return
+			}
+			if size := r.URL.Query().Get("page_size"); size != "50" {
+				t.Errorf("expected page size %d; got %s\n", 50, size)
+			}
+			fmt.Fprint(w, `{"count": 3, "next": "/event/1/tickets?page=2", "results": [{"id": 1}, {"id": 2}]}`)
+		}
+	})
+	for id, expected := range map[int]int{1: 2, 2: 2, 3: 1} {
+		requests = nil
+		event, err := c.Event(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(event.Tickets) != expected {
+			t.Errorf("expected %d tickets for event %d; got %v\n", expected, id, event.Tickets)
+		}
+		if fetched := len(requests) > 1; fetched != (id != 3) {
+			t.Errorf("unexpected requests for event %d: %v\n", id, requests)
+		}
+	}
+	all, err := c.AllTickets(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected 3 tickets; got %v\n", all)
+	}
+}
+
+func TestGetEventsBatch(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var ids []int
+		json.NewDecoder(r.Body).Decode(&ids)
+		events := map[int]Event{}
+		for _, id := range ids {
+			if id != 3 {
+				events[id] = Event{ID: id}
+			}
+		}
+		json.NewEncoder(w).Encode(events)
+	})
+	ids := []int{4, 3, 2, 1, 5}
+	events, errs := c.GetEventsBatch(context.Background(), ids, WithBatchSize(2))
+	if requests != 3 {
+		t.Errorf("expected %d requests; got %d\n", 3, requests)
+	}
+	for i, id := range ids {
+		if id == 3 {
+			if notFound := new(NotFoundError); !errors.As(errs[i], &notFound) || events[i] != nil {
+				t.Errorf("expected *NotFoundError; got %v\n", errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil || events[i].ID != id {
+			t.Errorf("expected event %d; got %v (%v)\n", id, events[i], errs[i])
+		}
+	}
+}
+
+func TestGetEventsBatchFallback(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusMethodNotAllowed} {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/events/batch" {
+				w.WriteHeader(status)
+				return
+			}
+			fmt.Fprintf(w, `{"id": %s, "tickets": []}`, strings.TrimPrefix(r.URL.Path, "/event/"))
+		})
+		ids := []int{1, 2, 3}
+		events, errs := c.GetEventsBatch(context.Background(), ids)
+		for i, id := range ids {
+			if errs[i] != nil || events[i].ID != id {
+				t.Errorf("expected event %d; got %v (%v)\n", id, events[i], errs[i])
+			}
+		}
+	}
+
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "no events found", "code": "events_not_found"}`)
+	})
+	ids := []int{1, 2}
+	events, errs := c.GetEventsBatch(context.Background(), ids)
+	if requests != 1 {
+		t.Errorf("expected %d request; got %d\n", 1, requests)
+	}
+	for i := range ids {
+		if notFound := new(NotFoundError); !errors.As(errs[i], &notFound) || events[i] != nil {
+			t.Errorf("expected *NotFoundError; got %v\n", errs[i])
+		}
+	}
+}
+
+func TestGetEventBySlug(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/event/slug/summer-ball":
+			fmt.Fprint(w, `{"id": 1, "slug": "summer-ball"}`)
+		case "/events/search":
+			fmt.Fprint(w, `{"count": 2, "results": [{"id": 2, "slug": "winter-ball-2"}, {"id": 3, "slug": "winter-ball"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	for slug, expected := range map[string]int{"summer-ball": 1, "winter-ball": 3} {
+		event, err := c.GetEventBySlug(context.Background(), slug)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event.ID != expected || event.Slug != slug {
+			t.Errorf("expected %d (%s); got %d (%s)\n", expected, slug, event.ID, event.Slug)
+		}
+	}
+	notFound := new(NotFoundError)
+	if _, err := c.GetEventBySlug(context.Background(), "spring/ball"); !errors.As(err, &notFound) {
+		t.Errorf("expected *NotFoundError; got %v\n", err)
+	}
+}
@@ -0,0 +1,97 @@
+package fixr
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The columns which can be exported by ExportAttendeesCSV (see WithExportColumns).
+const (
+	ColumnBookingID  = "BookingID"
+	ColumnName       = "Name"
+	ColumnEmail      = "Email"
+	ColumnTicketType = "TicketType"
+	ColumnCheckedIn  = "CheckedIn"
+	ColumnQRCode     = "QRCode"
+)
+
+var attendeeColumns = map[string]func(Attendee) string{
+	ColumnBookingID:  func(a Attendee) string { return strconv.Itoa(a.BookingID) },
+	ColumnName:       func(a Attendee) string { return a.Name },
+	ColumnEmail:      func(a Attendee) string { return a.Email },
+	ColumnTicketType: func(a Attendee) string { return a.TicketName },
+	ColumnCheckedIn:  func(a Attendee) string { return strconv.FormatBool(a.CheckedIn) },
+	ColumnQRCode:     func(a Attendee) string { return a.QRCodeURL },
+}
+
+// ExportOption configures a single call to ExportAttendeesCSV.
+type ExportOption func(*exportOptions)
+
+type exportOptions struct {
+	columns     []string
+	failOnEmpty bool
+}
+
+// WithExportColumns sets the columns exported, in order. By default, every column is exported.
+func WithExportColumns(columns ...string) ExportOption {
+	return func(o *exportOptions) {
+		o.columns = columns
+	}
+}
+
+// WithFailOnEmpty sets whether an error is returned if the event has no attendees (the default).
+// If not, only the header row is written for such events.
+func WithFailOnEmpty(fail bool) ExportOption {
+	return func(o *exportOptions) {
+		o.failOnEmpty = fail
+	}
+}
+
+// ExportAttendeesCSV writes the attendees of the event with the given ID to dst as CSV (RFC 4180),
+// such as for checking attendees in without internet access. A header row is written first,
+// followed by a row for each attendee. A *ForbiddenError will be returned if the user is not the
+// event's organiser.
+func (c *Client) ExportAttendeesCSV(ctx context.Context, eventID int, dst io.Writer, opts ...ExportOption) error {
+	if dst == nil {
+		return errors.New("no destination given")
+	}
+	options := exportOptions{
+		columns:     []string{ColumnBookingID, ColumnName, ColumnEmail, ColumnTicketType, ColumnCheckedIn, ColumnQRCode},
+		failOnEmpty: true,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	for _, column := range options.columns {
+		if _, ok := attendeeColumns[column]; !ok {
+			return errors.Errorf("unknown column %q", column)
+		}
+	}
+	var attendees []Attendee
+	it := c.IterateAttendees(eventID, 0)
+	for it.Next(ctx) {
+		attendees = append(attendees, it.Value()...)
+	}
+	if err := it.Err(); err != nil {
+		return errors.Wrap(err, "error exporting attendees")
+	}
+	if len(attendees) == 0 && options.failOnEmpty {
+		return errors.Errorf("event %d has no attendees", eventID)
+	}
+	w := csv.NewWriter(dst)
+	w.UseCRLF = true
+	w.Write(options.columns)
+	for _, a := range attendees {
+		row := make([]string, len(options.columns))
+		for i, column := range options.columns {
+			row[i] = attendeeColumns[column](a)
+		}
+		w.Write(row)
+	}
+	w.Flush()
+	return errors.Wrap(w.Error(), "error writing attendees")
+}
@@ -0,0 +1,56 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestExportAttendeesCSV(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/event/2/attendees" {
+			fmt.Fprint(w, `{"next": null, "results": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"next": null, "results": [
+			{"booking_id": 1, "name": "Ada Lovelace", "email": "ada@example.com", "ticket_name": "Standard Entry", "checked_in": true, "qr_code_url": "https://fixr.co/qr/1"},
+			{"booking_id": 2, "name": "Lovelace, Ada \"Jr\"", "ticket_name": "VIP"}
+		]}`)
+	})
+	c.AuthToken = "abc"
+	ctx := context.Background()
+	buf := new(bytes.Buffer)
+	if err := c.ExportAttendeesCSV(ctx, 1, buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "BookingID,Name,Email,TicketType,CheckedIn,QRCode\r\n" +
+		"1,Ada Lovelace,ada@example.com,Standard Entry,true,https://fixr.co/qr/1\r\n" +
+		"2,\"Lovelace, Ada \"\"Jr\"\"\",,VIP,false,\r\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q; got %q\n", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := c.ExportAttendeesCSV(ctx, 1, buf, WithExportColumns(ColumnName, ColumnCheckedIn)); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "Name,CheckedIn\r\nAda Lovelace,true\r\n\"Lovelace, Ada \"\"Jr\"\"\",false\r\n"; buf.String() != expected {
+		t.Errorf("expected %q; got %q\n", expected, buf.String())
+	}
+
+	if err := c.ExportAttendeesCSV(ctx, 2, buf); err == nil {
+		t.Error("expected an empty attendee list to fail")
+	}
+	buf.Reset()
+	if err := c.ExportAttendeesCSV(ctx, 2, buf, WithFailOnEmpty(false), WithExportColumns(ColumnBookingID)); err != nil || buf.String() != "BookingID\r\n" {
+		t.Errorf("expected only a header; got %q (%v)\n", buf.String(), err)
+	}
+	if err := c.ExportAttendeesCSV(ctx, 1, nil); err == nil {
+		t.Error("expected a nil destination to fail")
+	}
+	if err := c.ExportAttendeesCSV(ctx, 1, buf, WithExportColumns("Phone")); err == nil {
+		t.Error("expected an unknown column to fail")
+	}
+}
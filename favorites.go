@@ -0,0 +1,42 @@
+package fixr
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// AddFavoriteEvent adds the event with the given ID to the user's favourites.
+// An *AuthError will be returned if the client is not authenticated.
+func (c *Client) AddFavoriteEvent(ctx context.Context, eventID int) error {
+	data, err := jsonifyPayload(payload{"event_id": eventID})
+	if err != nil {
+		return err
+	}
+	if err := c.post(ctx, c.url(favoritesPath), data, true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error adding favourite event")
+	}
+	return nil
+}
+
+// RemoveFavoriteEvent removes the event with the given ID from the user's favourites.
+// An *AuthError will be returned if the client is not authenticated.
+func (c *Client) RemoveFavoriteEvent(ctx context.Context, eventID int) error {
+	if err := c.del(ctx, c.url(favoritePath, eventID), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error removing favourite event")
+	}
+	return nil
+}
+
+// GetFavoriteEvents returns the user's favourite events, with IsFavorited set.
+// An *AuthError will be returned if the client is not authenticated.
+func (c *Client) GetFavoriteEvents(ctx context.Context) ([]Event, error) {
+	events := []Event{}
+	if err := c.get(ctx, c.url(favoritesPath), true, &listResponse{items: &events}); err != nil {
+		return nil, errors.Wrap(err, "error getting favourite events")
+	}
+	for i := range events {
+		events[i].IsFavorited = true
+	}
+	return events, nil
+}
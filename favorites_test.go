@@ -0,0 +1,55 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestFavoriteEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /user/favorites", "DELETE /user/favorites/1":
+		case "GET /user/favorites":
+			fmt.Fprint(w, `[{"id": 1}]`)
+		default:
+			t.Errorf("unexpected request %s %s\n", r.Method, r.URL.Path)
+		}
+	})
+	c.AuthToken = "abc"
+	if err := c.AddFavoriteEvent(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	events, err := c.GetFavoriteEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != 1 || !events[0].IsFavorited {
+		t.Errorf("expected favourite event %d; got %v\n", 1, events)
+	}
+	if err := c.RemoveFavoriteEvent(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	c.AuthToken = ""
+	authErr := new(AuthError)
+	if _, err := c.GetFavoriteEvents(context.Background()); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+}
+
+func TestGetFavoriteEventsEmpty(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	c.AuthToken = "abc"
+	events, err := c.GetFavoriteEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events == nil || len(events) != 0 {
+		t.Errorf("expected empty (non-nil) events; got %#v\n", events)
+	}
+}
@@ -0,0 +1,48 @@
+package fixr
+
+import (
+	"context"
+	"time"
+)
+
+// healthCheckTimeout is the longest HealthCheck will wait, whatever the context's deadline.
+const healthCheckTimeout = 5 * time.Second
+
+// HealthResult contains the outcome of HealthCheck. AuthValid is only checked if the
+// client has an AuthToken. Error is the first error encountered, if any.
+type HealthResult struct {
+	APIReachable bool
+	AuthValid    bool
+	Latency      time.Duration
+	Error        error
+
+	authChecked bool
+}
+
+// IsHealthy reports whether every check performed by HealthCheck passed.
+func (r *HealthResult) IsHealthy() bool {
+	return r.APIReachable && (r.AuthValid || !r.authChecked) && r.Error == nil
+}
+
+// HealthCheck checks that FIXR is reachable (with an unauthenticated request to its home page)
+// and, if the client has an AuthToken, that the token is still valid (by requesting the user's
+// details), such as for a service's health endpoint. Latency is the time taken by the
+// unauthenticated request. The checks take at most 5 seconds.
+func (c *Client) HealthCheck(ctx context.Context) *HealthResult {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	result := new(HealthResult)
+	start := time.Now()
+	result.Error = c.probe(ctx, homeURL)
+	result.Latency = time.Since(start)
+	result.APIReachable = result.Error == nil
+	if len(c.AuthToken) == 0 {
+		return result
+	}
+	err := c.ValidateToken(ctx)
+	result.authChecked, result.AuthValid = true, err == nil
+	if result.Error == nil {
+		result.Error = err
+	}
+	return result
+}
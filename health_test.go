@@ -0,0 +1,69 @@
+package fixr
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func newHealthTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	c, err := New("test@example.com", WithMaxRetries(0), WithHTTPClient(&http.Client{Transport: handlerTransport{handler}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestHealthCheck(t *testing.T) {
+	var requests []string
+	c := newHealthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.String())
+		switch r.URL.String() {
+		case homeURL:
+			if auth := r.Header.Get("Authorization"); len(auth) > 0 {
+				t.Errorf("expected no Authorization header; got %s\n", auth)
+			}
+			w.Write([]byte(`<html></html>`))
+		case DefaultBaseURL + mePath:
+			if r.Header.Get("Authorization") != "Token valid" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	for _, test := range []struct {
+		token     string
+		authValid bool
+		healthy   bool
+		requests  int
+	}{
+		{"", false, true, 1},
+		{"valid", true, true, 2},
+		{"expired", false, false, 2},
+	} {
+		requests = nil
+		c.AuthToken = test.token
+		result := c.HealthCheck(context.Background())
+		if !result.APIReachable || result.AuthValid != test.authValid || result.IsHealthy() != test.healthy {
+			t.Errorf("expected reachable (auth: %t; healthy: %t) for %q; got %+v\n", test.authValid, test.healthy, test.token, result)
+		}
+		if len(requests) != test.requests || requests[0] != homeURL {
+			t.Errorf("expected %d requests, starting with %s; got %v\n", test.requests, homeURL, requests)
+		}
+	}
+
+	c = newHealthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.String() == homeURL {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	c.AuthToken = "valid"
+	if result := c.HealthCheck(context.Background()); result.APIReachable || !result.AuthValid || result.IsHealthy() || result.Error == nil {
+		t.Errorf("expected unreachable with a valid token; got %+v\n", result)
+	}
+}
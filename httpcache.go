@@ -0,0 +1,141 @@
+package fixr
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const defaultCacheSize = 100
+
+// Cache stores HTTP responses for CachingTransport, keyed by URL.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, val []byte)
+}
+
+// MemoryCache is an in-memory Cache which evicts the least recently used entry once full.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key string
+	val []byte
+}
+
+// NewMemoryCache returns a *MemoryCache holding up to maxEntries responses
+// (100 if maxEntries is not positive).
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheSize
+	}
+	return &MemoryCache{maxEntries: maxEntries, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get returns the value stored for key, if present.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheEntry).val, true
+}
+
+// Set stores val for key, evicting the least recently used entry if the cache is full.
+func (m *MemoryCache) Set(key string, val []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryCacheEntry).val = val
+		m.order.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key, val})
+	if m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// WithResponseCache revalidates GET responses stored in cache (see CachingTransport),
+// rather than downloading unchanged resources again. If cache is nil, a MemoryCache
+// holding 100 responses is used.
+func WithResponseCache(cache Cache) ClientOption {
+	return func(c *Client) error {
+		if cache == nil {
+			cache = NewMemoryCache(defaultCacheSize)
+		}
+		c.transports = append(c.transports, func(rt http.RoundTripper) http.RoundTripper {
+			return &CachingTransport{Base: rt, Cache: cache}
+		})
+		return nil
+	}
+}
+
+// CachingTransport is an http.RoundTripper which stores GET responses carrying an ETag or
+// Last-Modified header. Later requests for the same URL are made conditional, and the stored
+// response is returned if the server replies 304 Not Modified.
+// Responses are keyed by URL alone, so a Cache should not be shared between users.
+type CachingTransport struct {
+	// Base is the RoundTripper used to make requests (http.DefaultTransport if nil).
+	Base  http.RoundTripper
+	Cache Cache
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := orDefaultTransport(t.Base)
+	if req.Method != http.MethodGet {
+		return base.RoundTrip(req)
+	}
+	key := req.URL.String()
+	cached, _ := t.cached(key, req)
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); len(etag) > 0 {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); len(modified) > 0 {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		return cached, nil
+	}
+	if resp.StatusCode == http.StatusOK && (len(resp.Header.Get("ETag")) > 0 || len(resp.Header.Get("Last-Modified")) > 0) {
+		// DumpResponse replaces the body it consumes, so resp remains readable.
+		dump, err := httputil.DumpResponse(resp, true)
+		if err != nil {
+			resp.Body.Close()
+			return nil, errors.Wrap(err, "error caching response")
+		}
+		t.Cache.Set(key, dump)
+	}
+	return resp, nil
+}
+
+func (t *CachingTransport) cached(key string, req *http.Request) (*http.Response, error) {
+	dump, ok := t.Cache.Get(key)
+	if !ok {
+		return nil, nil
+	}
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(dump)), req)
+}
@@ -0,0 +1,51 @@
+package fixr
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestCachingTransport(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "test", "tickets": []}`)
+	}, WithResponseCache(nil))
+	for i := 0; i < 2; i++ {
+		e, err := c.Event(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Name != "test" {
+			t.Errorf("expected %s; got %s\n", "test", e.Name)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected %d; got %d\n", 2, requests)
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+	for i := 0; i < 3; i++ {
+		cache.Set(strconv.Itoa(i), []byte{byte(i)})
+		if i == 1 {
+			cache.Get("0")
+		}
+	}
+	if _, ok := cache.Get("1"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	for _, key := range []string{"0", "2"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached\n", key)
+		}
+	}
+}
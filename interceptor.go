@@ -0,0 +1,23 @@
+package fixr
+
+import "net/http"
+
+// RequestInterceptor is called with every request made by a client after its headers have
+// been set, such as to sign the request. Returning an error aborts the request.
+type RequestInterceptor func(*http.Request) error
+
+// ResponseInterceptor is called with every response received by a client (including
+// unsuccessful ones) before it is decoded. Returning an error discards the response.
+type ResponseInterceptor func(*http.Response) error
+
+// UseRequestInterceptor adds i to the end of the client's request interceptors, which are
+// run in the order they were added. It must not be called while requests are in progress.
+func (c *Client) UseRequestInterceptor(i RequestInterceptor) {
+	c.requestInterceptors = append(c.requestInterceptors, i)
+}
+
+// UseResponseInterceptor adds i to the end of the client's response interceptors, which are
+// run in the order they were added. It must not be called while requests are in progress.
+func (c *Client) UseResponseInterceptor(i ResponseInterceptor) {
+	c.responseInterceptors = append(c.responseInterceptors, i)
+}
@@ -0,0 +1,71 @@
+package fixr
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestInterceptors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Signature-Valid", r.Header.Get("X-Signature"))
+		serveFile(t, "testdata/event.json")(w, r)
+	})
+	var order []string
+	c.UseRequestInterceptor(func(req *http.Request) error {
+		order = append(order, "request 1")
+		req.Header.Set("X-Signature", "signed")
+		return nil
+	})
+	c.UseRequestInterceptor(func(req *http.Request) error {
+		order = append(order, "request 2")
+		return nil
+	})
+	c.UseResponseInterceptor(func(resp *http.Response) error {
+		order = append(order, "response "+resp.Header.Get("X-Signature-Valid"))
+		return nil
+	})
+	if _, err := c.Event(141151926); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"request 1", "request 2", "response signed"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v; got %v\n", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected %v; got %v\n", expected, order)
+		}
+	}
+}
+
+func TestInterceptorErrors(t *testing.T) {
+	errAudit := errors.New("audit log unavailable")
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		serveFile(t, "testdata/event.json")(w, r)
+	})
+	c.UseRequestInterceptor(func(*http.Request) error {
+		return errAudit
+	})
+	c.UseRequestInterceptor(func(*http.Request) error {
+		t.Error("expected interceptors to stop after the first error")
+		return nil
+	})
+	if _, err := c.Event(1); errors.Cause(err) != errAudit {
+		t.Errorf("expected %v; got %v\n", errAudit, err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests; got %d\n", requests)
+	}
+
+	c = newTestClient(t, serveFile(t, "testdata/event.json"))
+	c.UseResponseInterceptor(func(*http.Response) error {
+		return errAudit
+	})
+	if _, err := c.Event(1); errors.Cause(err) != errAudit {
+		t.Errorf("expected %v; got %v\n", errAudit, err)
+	}
+}
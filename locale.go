@@ -0,0 +1,38 @@
+package fixr
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+// SetLocale sets the BCP 47 locale (e.g. "en-GB") sent as the Accept-Language header of
+// every subsequent request, so that the API responds in the user's language.
+func (c *Client) SetLocale(locale string) error {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return &ValidationError{Field: "locale", Message: err.Error()}
+	}
+	c.locale = tag.String()
+	return nil
+}
+
+// SetCurrency sets the ISO 4217 currency code (e.g. "GBP") in which the API is asked
+// to display prices in every subsequent request.
+func (c *Client) SetCurrency(code string) error {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return &ValidationError{Field: "currency", Message: err.Error()}
+	}
+	c.currency = strings.ToUpper(unit.String())
+	return nil
+}
+
+// WithLocale sets the locale of a new client (see SetLocale).
+func WithLocale(locale string) ClientOption {
+	return func(c *Client) error {
+		return errors.Wrap(c.SetLocale(locale), "invalid locale")
+	}
+}
@@ -0,0 +1,37 @@
+package fixr
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestLocale(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if lang := r.Header.Get("Accept-Language"); lang != "fr-FR" {
+			t.Errorf("expected %s; got %s\n", "fr-FR", lang)
+		}
+		if cur := r.Header.Get("FIXR-Currency"); cur != "EUR" {
+			t.Errorf("expected %s; got %s\n", "EUR", cur)
+		}
+		fmt.Fprint(w, `{}`)
+	}, WithLocale("fr-FR"))
+	if err := c.SetCurrency("eur"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Event(1); err != nil {
+		t.Fatal(err)
+	}
+	validationErr := new(ValidationError)
+	if err := c.SetLocale("not a locale"); !errors.As(err, &validationErr) {
+		t.Errorf("expected *ValidationError; got %v\n", err)
+	}
+	if err := c.SetCurrency("XYZW"); !errors.As(err, &validationErr) {
+		t.Errorf("expected *ValidationError; got %v\n", err)
+	}
+	if _, err := New("test@example.com", WithLocale("not a locale")); err == nil {
+		t.Error("expected invalid locale to fail")
+	}
+}
@@ -0,0 +1,136 @@
+package fixr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Logger is implemented by structured loggers which can record the client's activity.
+// Fields are given as alternating keys and values, as with log/slog.
+type Logger interface {
+	Debug(msg string, fields ...interface{})
+	Info(msg string, fields ...interface{})
+	Warn(msg string, fields ...interface{})
+	Error(msg string, fields ...interface{})
+}
+
+// discardLogger is the default Logger, which discards all output.
+type discardLogger struct{}
+
+func (discardLogger) Debug(string, ...interface{}) {}
+func (discardLogger) Info(string, ...interface{})  {}
+func (discardLogger) Warn(string, ...interface{})  {}
+func (discardLogger) Error(string, ...interface{}) {}
+
+// WithLogger logs the client's activity, including every HTTP request it makes
+// (see LoggingTransport), to l. By default, nothing is logged.
+func WithLogger(l Logger) ClientOption {
+	return func(c *Client) error {
+		if l == nil {
+			return errors.New("logger cannot be nil")
+		}
+		c.logger = l
+		c.transports = append(c.transports, func(rt http.RoundTripper) http.RoundTripper {
+			return &LoggingTransport{Base: rt, Logger: l}
+		})
+		return nil
+	}
+}
+
+// LoggingTransport is an http.RoundTripper which logs the method, URL, status code
+// and latency of each request, along with its headers (with Authorization redacted).
+// Successful requests are logged at the Info level, unsuccessful responses at Warn and
+// requests which fail without a response at Error.
+type LoggingTransport struct {
+	// Base is the RoundTripper used to make requests (http.DefaultTransport if nil).
+	Base   http.RoundTripper
+	Logger Logger
+}
+
+var redactedHeaders = []string{"Authorization"}
+
+func redactHeaders(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range redactedHeaders {
+		if len(h.Get(key)) > 0 {
+			h.Set(key, "[REDACTED]")
+		}
+	}
+	return h
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := orDefaultTransport(t.Base).RoundTrip(req)
+	fields := []interface{}{
+		"method", req.Method,
+		"url", req.URL.String(),
+		"headers", redactHeaders(req.Header),
+		"latency", time.Since(start),
+	}
+	if err != nil {
+		t.Logger.Error("FIXR request failed", append(fields, "error", err)...)
+		return nil, err
+	}
+	fields = append(fields, "status", resp.StatusCode)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		t.Logger.Warn("FIXR request unsuccessful", fields...)
+		return resp, nil
+	}
+	t.Logger.Info("FIXR request", fields...)
+	return resp, nil
+}
+
+// SimpleLogger is a Logger which writes each message as a line of text to an io.Writer.
+type SimpleLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewSimpleLogger returns a *SimpleLogger writing to w (e.g. os.Stderr).
+func NewSimpleLogger(w io.Writer) *SimpleLogger {
+	return &SimpleLogger{w: w}
+}
+
+// Debug writes msg and fields to the underlying writer at the DEBUG level.
+func (l *SimpleLogger) Debug(msg string, fields ...interface{}) {
+	l.write("DEBUG", msg, fields)
+}
+
+// Info writes msg and fields to the underlying writer at the INFO level.
+func (l *SimpleLogger) Info(msg string, fields ...interface{}) {
+	l.write("INFO", msg, fields)
+}
+
+// Warn writes msg and fields to the underlying writer at the WARN level.
+func (l *SimpleLogger) Warn(msg string, fields ...interface{}) {
+	l.write("WARN", msg, fields)
+}
+
+// Error writes msg and fields to the underlying writer at the ERROR level.
+func (l *SimpleLogger) Error(msg string, fields ...interface{}) {
+	l.write("ERROR", msg, fields)
+}
+
+func (l *SimpleLogger) write(level, msg string, fields []interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s", time.Now().Format(time.RFC3339), level, msg)
+	for i := 0; i < len(fields); i += 2 {
+		if i+1 < len(fields) {
+			fmt.Fprintf(&b, " %v=%v", fields[i], fields[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", fields[i])
+		}
+	}
+	b.WriteByte('\n')
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, b.String())
+}
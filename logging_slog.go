@@ -0,0 +1,34 @@
+//go:build go1.21
+
+package fixr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogLogger adapts a slog.Logger to the Logger interface.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger returns a Logger which writes to h, for use with WithLogger.
+func NewSlogLogger(h slog.Handler) Logger {
+	return slogLogger{slog.New(h)}
+}
+
+func (s slogLogger) Debug(msg string, fields ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelDebug, msg, fields...)
+}
+
+func (s slogLogger) Info(msg string, fields ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelInfo, msg, fields...)
+}
+
+func (s slogLogger) Warn(msg string, fields ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelWarn, msg, fields...)
+}
+
+func (s slogLogger) Error(msg string, fields ...interface{}) {
+	s.l.Log(context.Background(), slog.LevelError, msg, fields...)
+}
@@ -0,0 +1,28 @@
+//go:build go1.21
+
+package fixr
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	h := slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to be made")
+	}, WithLogger(NewSlogLogger(h)), WithDryRun())
+	if _, err := c.Book(&Ticket{ID: 1, Max: 1}, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	for _, expected := range []string{"level=DEBUG", `msg="booking ticket"`, "ticket=1", "amount=1"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %s in %s\n", expected, output)
+		}
+	}
+}
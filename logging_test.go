@@ -0,0 +1,52 @@
+package fixr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	buf := new(bytes.Buffer)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}, WithLogger(NewSimpleLogger(buf)))
+	c.AuthToken = "secret"
+	c.Promo(1, "CODE")
+	output := buf.String()
+	for _, expected := range []string{"method=GET", "/promo_code/1/CODE", "status=200", "latency=", "[REDACTED]"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %s in %s\n", expected, output)
+		}
+	}
+	if strings.Contains(output, "secret") {
+		t.Errorf("expected auth token to be redacted in %s\n", output)
+	}
+}
+
+func TestLoggingTransportLevels(t *testing.T) {
+	buf := new(bytes.Buffer)
+	transport := &LoggingTransport{
+		Base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/ok":
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			case "/missing":
+				return &http.Response{StatusCode: http.StatusNotFound}, nil
+			}
+			return nil, errors.New("connection refused")
+		}),
+		Logger: NewSimpleLogger(buf),
+	}
+	for path, level := range map[string]string{"/ok": "INFO", "/missing": "WARN", "/down": "ERROR"} {
+		buf.Reset()
+		req, _ := http.NewRequest("GET", "https://example.com"+path, nil)
+		transport.RoundTrip(req)
+		if output := buf.String(); !strings.Contains(output, " "+level+" ") {
+			t.Errorf("expected %s to be logged at %s; got %s\n", path, level, output)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package fixr
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const maxMaintenanceDelay = time.Minute
+
+// checkMaintenance makes a lightweight request to determine whether the API is available.
+func (c *Client) checkMaintenance(ctx context.Context) error {
+	return c.probe(ctx, c.url(featuredPath))
+}
+
+// probe makes an unauthenticated GET request to addr, discarding the response body.
+func (c *Client) probe(ctx context.Context, addr string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", addr, nil)
+	if err != nil {
+		return errors.New("error creating GET request")
+	}
+	resp, err := c.do(req, false)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// WaitForMaintenance blocks until the FIXR API is no longer down for maintenance (see
+// MaintenanceError), checking again at the expected end of the maintenance or, if that has
+// passed, backing off exponentially from the client's retry delay (see WithRetry; 500ms if
+// zero), up to a minute. Errors unrelated to maintenance are returned, as is the context's
+// error if ctx is done first.
+func (c *Client) WaitForMaintenance(ctx context.Context) error {
+	delay := c.retryDelay
+	if delay <= 0 {
+		// Without a delay, the API would be checked continuously once the end time has passed.
+		delay = defaultRetryDelay
+	}
+	for {
+		err := c.checkMaintenance(ctx)
+		maintenanceErr := new(MaintenanceError)
+		if !errors.As(err, &maintenanceErr) {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+		wait := delay
+		if until := time.Until(maintenanceErr.EndTime); until > wait {
+			wait = until
+		}
+		if delay *= 2; delay > maxMaintenanceDelay {
+			delay = maxMaintenanceDelay
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestMaintenanceError(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusServiceUnavailable, `{"detail": "Down for maintenance.", "maintenance_end_time": "2020-01-01T06:00:00Z"}`))
+	_, err := c.Event(1)
+	maintenanceErr := new(MaintenanceError)
+	if !errors.As(err, &maintenanceErr) {
+		t.Fatalf("expected *MaintenanceError; got %T\n", errors.Cause(err))
+	}
+	if expected := time.Date(2020, 1, 1, 6, 0, 0, 0, time.UTC); !maintenanceErr.EndTime.Equal(expected) {
+		t.Errorf("expected %v; got %v\n", expected, maintenanceErr.EndTime)
+	}
+	c = newTestClient(t, statusHandler(http.StatusServiceUnavailable, `{}`))
+	if _, err := c.Event(1); errors.As(err, &maintenanceErr) {
+		t.Errorf("expected plain *APIError; got %v\n", err)
+	}
+}
+
+func TestWaitForMaintenance(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests++; requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"maintenance_end_time": "2020-01-01T06:00:00Z"}`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}, WithRetry(0, time.Millisecond))
+	if err := c.WaitForMaintenance(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("expected %d; got %d\n", 3, requests)
+	}
+}
+
+func TestWaitForMaintenanceZeroRetryDelay(t *testing.T) {
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"maintenance_end_time": "2020-01-01T06:00:00Z"}`)
+	}, WithRetry(0, 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := c.WaitForMaintenance(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v; got %v\n", context.DeadlineExceeded, err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected %d request; got %d\n", 1, n)
+	}
+}
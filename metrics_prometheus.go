@@ -0,0 +1,124 @@
+//go:build fixr_prometheus
+// +build fixr_prometheus
+
+package fixr
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "fixr_http_requests_total",
+		Help: "Number of HTTP requests made to the FIXR API.",
+	}, []string{"method", "endpoint", "status_code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fixr_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests made to the FIXR API.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})
+
+	// Endpoint labels are taken from the API's path templates rather than the request path,
+	// so that IDs, slugs and promo codes never reach them (which would also leave their
+	// cardinality unbounded).
+	routes = newRoutes(
+		bookingPath, bookingIDPath, cancelPath, resendPath, sharePath, historyPath, transferPath,
+		acceptPath, promoPath, loginPath, signupPath, eventPath, newEventPath, slugPath, ticketPath,
+		ticketsPath, waitlistPath, venuePath, organizerPath, revenuePath, attendeesPath, checkInPath,
+		followPath, searchPath, batchPath, featuredPath, upcomingPath, categoryPath, recommendPath,
+		tokenPath, cardPath, defaultPath, mePath, statsPath, avatarPath, logoutPath, favoritesPath,
+		favoritePath, passwordPath, resetRequestPath, resetPasswordPath, stripeConfigPath,
+		eventPromoPath, versionPath, appleLoginPath, checkInStatsPath, notificationsPath,
+		notificationPath, readAllPath, deleteUserPath, dataExportPath,
+	)
+)
+
+// otherEndpoint is the endpoint label of requests which match none of the routes, such as
+// PDFs and QR codes hosted elsewhere.
+const otherEndpoint = "other"
+
+// route matches request paths against a path template (e.g. "/event/%d").
+type route struct {
+	pattern *regexp.Regexp
+	label   string
+}
+
+func newRoutes(templates ...string) []route {
+	routes := make([]route, len(templates))
+	for i, template := range templates {
+		pattern := strings.NewReplacer("%d", `\d+`, "%s", `[^/]+`).Replace(regexp.QuoteMeta(template))
+		routes[i] = route{
+			// Any prefix is allowed, as the base URL may have a path (see WithBaseURL).
+			pattern: regexp.MustCompile("^.*" + pattern + "$"),
+			label:   strings.NewReplacer("%d", "{id}", "%s", "{key}").Replace(template),
+		}
+	}
+	return routes
+}
+
+// endpointLabel returns the label of the route matching path, or otherEndpoint.
+func endpointLabel(path string) string {
+	for _, r := range routes {
+		if r.pattern.MatchString(path) {
+			return r.label
+		}
+	}
+	return otherEndpoint
+}
+
+// MustRegisterMetrics registers the FIXR metrics with reg, panicking if they are already registered.
+// It is only available with the fixr_prometheus build tag.
+func MustRegisterMetrics(reg prometheus.Registerer) {
+	reg.MustRegister(requestsTotal, requestDuration)
+}
+
+// WithPrometheusMetrics records the client's requests with a MetricsTransport, registering the
+// metrics with reg (prometheus.DefaultRegisterer if nil) unless they are already registered.
+// It is only available with the fixr_prometheus build tag.
+func WithPrometheusMetrics(reg prometheus.Registerer) ClientOption {
+	return func(c *Client) error {
+		if reg == nil {
+			reg = prometheus.DefaultRegisterer
+		}
+		for _, collector := range []prometheus.Collector{requestsTotal, requestDuration} {
+			if err := reg.Register(collector); err != nil {
+				if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+					return errors.Wrap(err, "error registering metrics")
+				}
+			}
+		}
+		c.transports = append(c.transports, func(rt http.RoundTripper) http.RoundTripper {
+			return &MetricsTransport{Base: rt}
+		})
+		return nil
+	}
+}
+
+// MetricsTransport is an http.RoundTripper which records fixr_http_requests_total and
+// fixr_http_request_duration_seconds for each request.
+type MetricsTransport struct {
+	// Base is the RoundTripper used to make requests (http.DefaultTransport if nil).
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *MetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := orDefaultTransport(t.Base).RoundTrip(req)
+	endpoint := endpointLabel(req.URL.Path)
+	requestDuration.WithLabelValues(req.Method, endpoint).Observe(time.Since(start).Seconds())
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	requestsTotal.WithLabelValues(req.Method, endpoint, status).Inc()
+	return resp, err
+}
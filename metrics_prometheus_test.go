@@ -0,0 +1,87 @@
+//go:build fixr_prometheus
+// +build fixr_prometheus
+
+package fixr
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEndpointLabel(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/event/42":                         "/event/{id}",
+		"/event":                            "/event",
+		"/event/slug/summer-ball":           "/event/slug/{key}",
+		"/event/42/promo_code/SECRET":       "/event/{id}/promo_code/{key}",
+		"/promo_code/7/SECRET":              "/promo_code/{id}/{key}",
+		"/stripe/card/card_123/default":     "/stripe/card/{key}/default",
+		"/stripe/config":                    "/stripe/config",
+		"/api/v2/booking/7/cancel":          "/booking/{id}/cancel",
+		"/notifications/read-all":           "/notifications/read-all",
+		"/tickets/7/ticket.pdf":             otherEndpoint,
+		"/event/42/promo_code/SECRET/extra": otherEndpoint,
+	} {
+		if label := endpointLabel(path); label != expected {
+			t.Errorf("expected %s; got %s (%s)\n", expected, label, path)
+		}
+	}
+}
+
+// TestRoutesComplete checks that every path constant in the package is one of the routes.
+func TestRoutesComplete(t *testing.T) {
+	pkgs, err := parser.ParseDir(gotoken.NewFileSet(), ".", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := make(map[string]bool)
+	for _, r := range routes {
+		labels[r.label] = true
+	}
+	for _, f := range pkgs["fixr"].Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range spec.Names {
+				if !strings.HasSuffix(name.Name, "Path") || i >= len(spec.Values) {
+					continue
+				}
+				lit, ok := spec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != gotoken.STRING {
+					continue
+				}
+				path, _ := strconv.Unquote(lit.Value)
+				if label := endpointLabel(strings.NewReplacer("%d", "1", "%s", "x").Replace(path)); !labels[label] {
+					t.Errorf("expected a route for %s (%s)\n", name.Name, path)
+				}
+			}
+			return true
+		})
+	}
+}
+
+func TestWithPrometheusMetrics(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code": "SECRET", "remaining": 1}`)
+	}, WithPrometheusMetrics(prometheus.NewRegistry()))
+	c.AuthToken = "abc"
+	counter := requestsTotal.WithLabelValues("GET", "/promo_code/{id}/{key}", "200")
+	before := testutil.ToFloat64(counter)
+	if _, err := c.Promo(1, "SECRET"); err != nil {
+		t.Fatal(err)
+	}
+	if after := testutil.ToFloat64(counter); after != before+1 {
+		t.Errorf("expected %.0f requests; got %.0f\n", before+1, after)
+	}
+}
@@ -0,0 +1,170 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BookRequest describes a single booking made as part of MultiBook.
+type BookRequest struct {
+	Ticket *Ticket
+	Amount int
+	Promo  *PromoCode
+}
+
+// MultiBookError is returned by MultiBook when one or more of the requests fail.
+// Errors has the same length and order as the requests, with nil entries for those that succeeded.
+type MultiBookError struct {
+	Errors []error
+}
+
+func (e *MultiBookError) Error() string {
+	failed, first := 0, error(nil)
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d bookings failed (first error: %v)", failed, len(e.Errors), first)
+}
+
+// MultiBook books several tickets (e.g. of different types) in one operation.
+// Every request is validated locally before any booking is made; if any are invalid,
+// a *MultiBookError is returned and nothing is booked. The bookings are then made in
+// parallel (see WithConcurrency) and returned in the same order as requests. If any fail,
+// the successful bookings are returned alongside a *MultiBookError.
+func (c *Client) MultiBook(ctx context.Context, requests []BookRequest) ([]*Booking, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("no bookings requested")
+	}
+	errs, failed := make([]error, len(requests)), false
+	for i, r := range requests {
+		if r.Ticket == nil {
+			errs[i], failed = errors.New("no ticket given"), true
+		} else if err := checkTicket(r.Ticket, r.Amount); err != nil {
+			errs[i], failed = err, true
+		}
+	}
+	if failed {
+		return nil, &MultiBookError{Errors: errs}
+	}
+	bookings := make([]*Booking, len(requests))
+	parallel(len(requests), c.concurrency, func(i int) {
+		r := requests[i]
+		bookings[i], errs[i] = c.BookWithContext(ctx, r.Ticket, r.Amount, r.Promo)
+	})
+	for _, err := range errs {
+		if err != nil {
+			return bookings, &MultiBookError{Errors: errs}
+		}
+	}
+	return bookings, nil
+}
+
+// BookAsync makes a booking (see Book) in a new goroutine. Exactly one value is sent on either
+// the booking or the error channel, after which both are closed. Callers should drain both
+// channels (a receive from a closed channel returns immediately); as each is buffered, the
+// goroutine will still exit if they are abandoned. Cancelling ctx aborts the booking request.
+func (c *Client) BookAsync(ctx context.Context, ticket *Ticket, amount int, promo *PromoCode) (<-chan *Booking, <-chan error) {
+	bookings, errs := make(chan *Booking, 1), make(chan error, 1)
+	go func() {
+		defer close(bookings)
+		defer close(errs)
+		booking, err := c.BookWithContext(ctx, ticket, amount, promo)
+		if err != nil {
+			errs <- err
+			return
+		}
+		bookings <- booking
+	}()
+	return bookings, errs
+}
+
+// BookingResult is the outcome of a single request made by BulkBook.
+// Index is the position of Request in the requests given to BulkBook.
+type BookingResult struct {
+	Index   int
+	Request BookRequest
+	Booking *Booking
+	Err     error
+}
+
+// BulkBook books each of requests using concurrency worker goroutines (or the client's
+// concurrency, see WithConcurrency, if not positive), sending every BookingResult to results
+// as it completes. Workers block until their result is received, so callers can apply back
+// pressure by receiving slowly. A worker whose booking is rate limited waits until the
+// RateLimitError's RetryAfter and tries again with the same purchase key. BulkBook returns
+// once every request has been processed, or with the context's error if ctx is done first.
+// It does not close results.
+func (c *Client) BulkBook(ctx context.Context, requests []BookRequest, concurrency int, results chan<- BookingResult) error {
+	if concurrency <= 0 {
+		concurrency = c.concurrency
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := BookingResult{Index: i, Request: requests[i]}
+				result.Booking, result.Err = c.bookRetryingRateLimit(ctx, requests[i])
+				select {
+				case results <- result:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	func() {
+		defer close(jobs)
+		for i := range requests {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	return ctx.Err()
+}
+
+// bookRetryingRateLimit makes the booking r, waiting and retrying while it is rate limited.
+func (c *Client) bookRetryingRateLimit(ctx context.Context, r BookRequest) (*Booking, error) {
+	if r.Ticket == nil {
+		return nil, errors.New("no ticket given")
+	}
+	key, err := NewPurchaseKey()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		booking, err := c.BookWithContext(ctx, r.Ticket, r.Amount, r.Promo, WithPurchaseKey(key))
+		rateErr := new(RateLimitError)
+		if !errors.As(err, &rateErr) {
+			return booking, err
+		}
+		wait := time.Until(rateErr.RetryAfter)
+		if wait <= 0 {
+			wait = c.retryDelay
+		}
+		if wait <= 0 {
+			wait = defaultRetryDelay
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package fixr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestMultiBookValidatesFirst(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{}`)
+	})
+	_, err := c.MultiBook(context.Background(), []BookRequest{
+		{Ticket: &Ticket{ID: 1, Max: 2}, Amount: 1},
+		{Ticket: &Ticket{ID: 2, Max: 2, SoldOut: true}, Amount: 1},
+	})
+	multiErr := new(MultiBookError)
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected *MultiBookError; got %v\n", err)
+	}
+	if multiErr.Errors[0] != nil || multiErr.Errors[1] == nil {
+		t.Errorf("expected only the second request to fail; got %v\n", multiErr.Errors)
+	}
+	if calls != 0 {
+		t.Errorf("expected %d requests; got %d\n", 0, calls)
+	}
+}
+
+func TestMultiBookPartialFailure(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var pl struct {
+			TicketID int `json:"ticket_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&pl)
+		if pl.TicketID == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"id": %d}`, pl.TicketID*10)
+	})
+	c.AuthToken = "abc"
+	bookings, err := c.MultiBook(context.Background(), []BookRequest{
+		{Ticket: &Ticket{ID: 1, Max: 2}, Amount: 1},
+		{Ticket: &Ticket{ID: 2, Max: 2}, Amount: 1},
+	})
+	multiErr := new(MultiBookError)
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected *MultiBookError; got %v\n", err)
+	}
+	if bookings[0] == nil || bookings[0].ID != 10 || bookings[1] != nil {
+		t.Errorf("expected only the first booking to succeed; got %v\n", bookings)
+	}
+}
+
+func TestBookAsync(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+	c.AuthToken = "abc"
+	bookings, errs := c.BookAsync(context.Background(), &Ticket{ID: 1, Max: 1}, 1, nil)
+	if b, err := <-bookings, <-errs; err != nil || b == nil || b.ID != 1 {
+		t.Errorf("expected booking %d; got %v (%v)\n", 1, b, err)
+	}
+	bookings, errs = c.BookAsync(context.Background(), &Ticket{ID: 1, Max: 1, SoldOut: true}, 1, nil)
+	soldOutErr := new(SoldOutError)
+	if b, err := <-bookings, <-errs; b != nil || !errors.As(err, &soldOutErr) {
+		t.Errorf("expected *SoldOutError; got %v (%v)\n", b, err)
+	}
+}
+
+func TestBulkBook(t *testing.T) {
+	var limited int32
+	keys := make(chan string, 2)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/booking" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		var pl struct {
+			TicketID    int    `json:"ticket_id"`
+			PurchaseKey string `json:"purchase_key"`
+		}
+		json.NewDecoder(r.Body).Decode(&pl)
+		if pl.TicketID == 2 {
+			keys <- pl.PurchaseKey
+			if atomic.AddInt32(&limited, 1) == 1 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		}
+		if pl.TicketID == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"id": %d}`, pl.TicketID*10)
+	})
+	c.AuthToken = "abc"
+	c.retryDelay = time.Millisecond
+	requests := []BookRequest{
+		{Ticket: &Ticket{ID: 1, Max: 1}, Amount: 1},
+		{Ticket: &Ticket{ID: 2, Max: 1}, Amount: 1},
+		{Ticket: &Ticket{ID: 3, Max: 1}, Amount: 1},
+	}
+	results := make(chan BookingResult)
+	done := make(chan error)
+	go func() {
+		done <- c.BulkBook(context.Background(), requests, 2, results)
+	}()
+	received := make([]BookingResult, len(requests))
+	for range requests {
+		result := <-results
+		received[result.Index] = result
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []int{10, 20} {
+		if received[i].Err != nil || received[i].Booking.ID != expected {
+			t.Errorf("expected booking %d; got %v (%v)\n", expected, received[i].Booking, received[i].Err)
+		}
+	}
+	if received[2].Err == nil {
+		t.Error("expected the third booking to fail")
+	}
+	if first, second := <-keys, <-keys; len(first) == 0 || first != second {
+		t.Errorf("expected the retry to reuse purchase key %s; got %s\n", first, second)
+	}
+}
+
+func TestBulkBookCancelled(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusOK, `{}`))
+	c.AuthToken = "abc"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	requests := []BookRequest{{Ticket: &Ticket{ID: 1, Max: 1}, Amount: 1}}
+	if err := c.BulkBook(ctx, requests, 1, make(chan BookingResult)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v; got %v\n", context.Canceled, err)
+	}
+}
@@ -0,0 +1,71 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Notification is a message sent to the user by FIXR, such as an event reminder.
+// EventID and BookingID are set for notifications about a specific event or booking,
+// and ReadAt once the notification has been read.
+type Notification struct {
+	ID        int        `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	EventID   *int       `json:"event_id"`
+	BookingID *int       `json:"booking_id"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationPage contains a single page of notifications, along with the total number of
+// unread notifications.
+type NotificationPage struct {
+	apiError
+	Items       []Notification `json:"results"`
+	UnreadCount int            `json:"unread_count"`
+	HasNextPage bool           `json:"-"`
+}
+
+type notificationPage struct {
+	NotificationPage
+	Next *string `json:"next"`
+}
+
+// GetNotifications returns the given page (starting at 1) of the user's notifications.
+func (c *Client) GetNotifications(ctx context.Context, page, pageSize int) (*NotificationPage, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, errors.New("page and page size must be positive")
+	}
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+	resp := notificationPage{}
+	if err := c.get(ctx, c.url(notificationsPath)+"?"+query.Encode(), true, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting notifications")
+	}
+	resp.HasNextPage = resp.Next != nil
+	return &resp.NotificationPage, nil
+}
+
+// MarkNotificationRead marks the notification with the given ID as read.
+func (c *Client) MarkNotificationRead(ctx context.Context, notificationID int) error {
+	if err := c.patch(ctx, c.url(notificationPath, notificationID), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error marking notification read")
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks all of the user's notifications as read.
+func (c *Client) MarkAllNotificationsRead(ctx context.Context) error {
+	if err := c.patch(ctx, c.url(readAllPath), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error marking notifications read")
+	}
+	return nil
+}
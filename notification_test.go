@@ -0,0 +1,50 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNotifications(t *testing.T) {
+	var patched []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			patched = append(patched, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.URL.Path != "/notifications" || r.URL.Query().Get("page") != "1" || r.URL.Query().Get("page_size") != "20" {
+			t.Errorf("unexpected request: %s\n", r.URL)
+		}
+		fmt.Fprint(w, `{"next": "/notifications?page=2", "unread_count": 3, "results": [
+			{"id": 1, "type": "event_reminder", "title": "Tomorrow", "event_id": 141151926, "created_at": "2017-06-15T19:00:00Z"},
+			{"id": 2, "type": "booking_confirmed", "booking_id": 7, "read_at": "2017-06-01T12:00:00Z"}
+		]}`)
+	})
+	c.AuthToken = "abc"
+	ctx := context.Background()
+	page, err := c.GetNotifications(ctx, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.UnreadCount != 3 || !page.HasNextPage || len(page.Items) != 2 {
+		t.Fatalf("unexpected page: %+v\n", page)
+	}
+	if n := page.Items[0]; n.EventID == nil || *n.EventID != 141151926 || n.BookingID != nil || n.ReadAt != nil {
+		t.Errorf("unexpected notification: %+v\n", n)
+	}
+	if n := page.Items[1]; n.BookingID == nil || *n.BookingID != 7 || n.ReadAt == nil {
+		t.Errorf("unexpected notification: %+v\n", n)
+	}
+	if err := c.MarkNotificationRead(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.MarkAllNotificationsRead(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[/notifications/1/read /notifications/read-all]"; fmt.Sprint(patched) != expected {
+		t.Errorf("expected %s; got %v\n", expected, patched)
+	}
+}
@@ -0,0 +1,100 @@
+package fixr
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ClientOption configures a Client created with New.
+type ClientOption func(*Client) error
+
+// WithHTTPClient sets the *http.Client used to execute API requests.
+// The client is copied, so later changes made by the caller are not observed.
+// It cannot be combined with WithMaxIdleConns, WithIdleConnTimeout or WithMaxConnsPerHost.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) error {
+		if hc == nil {
+			return errors.New("http client cannot be nil")
+		}
+		c.httpClient = hc
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for each request made by the client.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d < 0 {
+			return errors.New("timeout cannot be negative")
+		}
+		c.timeout = d
+		return nil
+	}
+}
+
+// WithBaseURL sets the root of the FIXR API (DefaultBaseURL by default).
+func WithBaseURL(addr string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return errors.Wrap(err, "invalid base URL")
+		}
+		if len(u.Scheme) == 0 || len(u.Host) == 0 {
+			return errors.Errorf("invalid base URL (%s)", addr)
+		}
+		c.baseURL = strings.TrimSuffix(addr, "/")
+		return nil
+	}
+}
+
+// WithUserAgent sets the user agent sent by the client in place of the global UserAgent.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) error {
+		c.userAgent = ua
+		return nil
+	}
+}
+
+// WithMaxRetries sets the number of times a failed request is retried (3 by default).
+// Zero disables retries. Only idempotent requests are retried, such as GETs and paid
+// bookings (which carry a purchase key); see WithPurchaseKey.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 0 {
+			return errors.New("retries cannot be negative")
+		}
+		c.maxRetries = n
+		return nil
+	}
+}
+
+// WithRetry retries failed requests up to maxAttempts times, backing off exponentially
+// (with full jitter) from baseDelay, or for longer if the API responds with a Retry-After of
+// up to 30 seconds; a longer Retry-After is returned as the response's error (e.g. a
+// *RateLimitError) without retrying. As with WithMaxRetries, only idempotent requests are retried. By default, requests are
+// retried 3 times from 500ms.
+func WithRetry(maxAttempts int, baseDelay time.Duration) ClientOption {
+	return func(c *Client) error {
+		if maxAttempts < 0 || baseDelay < 0 {
+			return errors.New("retry attempts and delay cannot be negative")
+		}
+		c.maxRetries, c.retryDelay = maxAttempts, baseDelay
+		return nil
+	}
+}
+
+// WithConcurrency sets the maximum number of requests made at once by methods which
+// fan out over several API calls, such as PromoCodes (5 by default).
+func WithConcurrency(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return errors.New("concurrency must be at least 1")
+		}
+		c.concurrency = n
+		return nil
+	}
+}
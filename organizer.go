@@ -0,0 +1,270 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Organizer contains the details of an event organiser.
+type Organizer struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Website     string `json:"website"`
+	ImageURL    string `json:"image_url"`
+}
+
+type organizerResponse struct {
+	apiError
+	Organizer
+}
+
+// GetEventOrganizer returns the organiser of the event with the given ID.
+// Organisers can be cached by the caller using Event.OrganizerID.
+func (c *Client) GetEventOrganizer(ctx context.Context, eventID int) (*Organizer, error) {
+	resp := organizerResponse{}
+	if err := c.get(ctx, c.url(organizerPath, eventID), false, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting organiser")
+	}
+	return &resp.Organizer, nil
+}
+
+// FollowOrganizer follows the organiser with the given ID.
+// An *AuthError will be returned if the client is not authenticated.
+func (c *Client) FollowOrganizer(ctx context.Context, organizerID int) error {
+	if err := c.requireAuth(); err != nil {
+		return err
+	}
+	if err := c.post(ctx, c.url(followPath, organizerID), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error following organiser")
+	}
+	return nil
+}
+
+// UnfollowOrganizer stops following the organiser with the given ID.
+// An *AuthError will be returned if the client is not authenticated.
+func (c *Client) UnfollowOrganizer(ctx context.Context, organizerID int) error {
+	if err := c.requireAuth(); err != nil {
+		return err
+	}
+	if err := c.del(ctx, c.url(followPath, organizerID), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error unfollowing organiser")
+	}
+	return nil
+}
+
+// RevenueReport contains the revenue of an event, for its organiser. All amounts are in Currency.
+type RevenueReport struct {
+	apiError
+	Currency         string          `json:"currency"`
+	TotalRevenue     float64         `json:"total_revenue"`
+	TotalBookingFees float64         `json:"total_booking_fees"`
+	PlatformFees     float64         `json:"platform_fees"`
+	TicketBreakdown  []TicketRevenue `json:"ticket_breakdown"`
+}
+
+// TicketRevenue contains the revenue from a single ticket type.
+type TicketRevenue struct {
+	TicketID     int     `json:"ticket_id"`
+	Name         string  `json:"name"`
+	QuantitySold int     `json:"quantity_sold"`
+	GrossRevenue float64 `json:"gross_revenue"`
+}
+
+// TotalNet returns the event's revenue after FIXR's platform fees.
+func (r *RevenueReport) TotalNet() float64 {
+	return r.TotalRevenue - r.PlatformFees
+}
+
+// GetEventRevenue returns the revenue report for the event with the given ID.
+// A *ForbiddenError will be returned if the user is not the event's organiser.
+func (c *Client) GetEventRevenue(ctx context.Context, eventID int) (*RevenueReport, error) {
+	report := RevenueReport{}
+	if err := c.get(ctx, c.url(revenuePath, eventID), true, &report); err != nil {
+		return nil, errors.Wrap(err, "error getting revenue")
+	}
+	return &report, nil
+}
+
+// CreateEventRequest contains the details of an event created with CreateEvent.
+type CreateEventRequest struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	VenueID     int       `json:"venue_id"`
+	MinAge      int       `json:"minimum_age,omitempty"`
+	ImageURL    string    `json:"image,omitempty"`
+	IsPublic    bool      `json:"is_public"`
+}
+
+// UpdateEventRequest contains the changes made to an event by UpdateEvent.
+// Nil fields are left unchanged.
+type UpdateEventRequest struct {
+	Name        *string    `json:"name,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	VenueID     *int       `json:"venue_id,omitempty"`
+	MinAge      *int       `json:"minimum_age,omitempty"`
+	ImageURL    *string    `json:"image,omitempty"`
+	IsPublic    *bool      `json:"is_public,omitempty"`
+}
+
+func validateEventDetails(name *string, startTime *time.Time) error {
+	if name != nil && len(strings.TrimSpace(*name)) == 0 {
+		return &ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	if startTime != nil && !startTime.After(time.Now()) {
+		return &ValidationError{Field: "start_time", Message: "must be in the future"}
+	}
+	return nil
+}
+
+// CreateEvent creates an event organised by the user, returning it with its ID.
+// A *ValidationError will be returned (without making a request) if req has no Name
+// or its StartTime is not in the future.
+func (c *Client) CreateEvent(ctx context.Context, req CreateEventRequest) (*Event, error) {
+	if err := validateEventDetails(&req.Name, &req.StartTime); err != nil {
+		return nil, err
+	}
+	data := new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(req); err != nil {
+		return nil, errors.Wrap(err, "error encoding event")
+	}
+	event := Event{}
+	if err := c.post(ctx, c.url(newEventPath), data, true, &event); err != nil {
+		return nil, errors.Wrap(err, "error creating event")
+	}
+	return &event, nil
+}
+
+// UpdateEvent changes the given details of the event with the given ID, returning the updated
+// event. The same validation as CreateEvent applies to the fields which are set, and a
+// *ForbiddenError will be returned if the user is not the event's organiser.
+func (c *Client) UpdateEvent(ctx context.Context, id int, req UpdateEventRequest) (*Event, error) {
+	if err := validateEventDetails(req.Name, req.StartTime); err != nil {
+		return nil, err
+	}
+	data := new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(req); err != nil {
+		return nil, errors.Wrap(err, "error encoding event")
+	}
+	event := Event{}
+	if err := c.patch(ctx, c.url(eventPath, id), data, true, &event); err != nil {
+		return nil, errors.Wrap(err, "error updating event")
+	}
+	c.InvalidateEventCache(id)
+	return &event, nil
+}
+
+// CreateTicketRequest contains the details of a ticket type created with CreateTicket.
+// A nil SaleStartTime or SaleEndTime leaves the sale window open at that end.
+type CreateTicketRequest struct {
+	Name          string     `json:"name"`
+	Type          int        `json:"type"`
+	Currency      string     `json:"currency"`
+	Price         float64    `json:"price"`
+	BookingFee    float64    `json:"booking_fee"`
+	Quantity      int        `json:"quantity"`
+	MaxPerUser    int        `json:"max_per_user"`
+	SaleStartTime *time.Time `json:"sale_start_time,omitempty"`
+	SaleEndTime   *time.Time `json:"sale_end_time,omitempty"`
+	Description   string     `json:"description"`
+}
+
+// UpdateTicketRequest contains the changes made to a ticket type by UpdateTicket.
+// Nil fields are left unchanged.
+type UpdateTicketRequest struct {
+	Name          *string    `json:"name,omitempty"`
+	Price         *float64   `json:"price,omitempty"`
+	BookingFee    *float64   `json:"booking_fee,omitempty"`
+	Quantity      *int       `json:"quantity,omitempty"`
+	MaxPerUser    *int       `json:"max_per_user,omitempty"`
+	SaleStartTime *time.Time `json:"sale_start_time,omitempty"`
+	SaleEndTime   *time.Time `json:"sale_end_time,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+}
+
+func validateTicketDetails(price *float64, quantity *int, saleStart, saleEnd *time.Time) error {
+	if price != nil && *price < 0 {
+		return &ValidationError{Field: "price", Message: "cannot be negative"}
+	}
+	if quantity != nil && *quantity <= 0 {
+		return &ValidationError{Field: "quantity", Message: "must be at least 1"}
+	}
+	if saleStart != nil && saleEnd != nil && !saleStart.IsZero() && !saleEnd.IsZero() && !saleEnd.After(*saleStart) {
+		return &ValidationError{Field: "sale_end_time", Message: "must be after sale_start_time"}
+	}
+	return nil
+}
+
+type ticketResponse struct {
+	apiError
+	Ticket
+	EventID int `json:"event_id"`
+}
+
+// invalidateTicketEvent evicts the event with the given ID, which owns a changed ticket type,
+// from the cache. Every event is evicted if the API did not report the owning event's ID.
+func (c *Client) invalidateTicketEvent(eventID int) {
+	if eventID > 0 {
+		c.InvalidateEventCache(eventID)
+		return
+	}
+	c.ClearEventCache()
+}
+
+// CreateTicket adds a ticket type to the event with the given ID, returning the new ticket.
+// A *ValidationError will be returned (without making a request) if the Price is negative,
+// the Quantity is not positive or the SaleEndTime is not after the SaleStartTime, and a
+// *ForbiddenError if the user is not the event's organiser.
+func (c *Client) CreateTicket(ctx context.Context, eventID int, req CreateTicketRequest) (*Ticket, error) {
+	if err := validateTicketDetails(&req.Price, &req.Quantity, req.SaleStartTime, req.SaleEndTime); err != nil {
+		return nil, err
+	}
+	data := new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(req); err != nil {
+		return nil, errors.Wrap(err, "error encoding ticket")
+	}
+	resp := ticketResponse{}
+	if err := c.post(ctx, c.url(ticketsPath, eventID), data, true, &resp); err != nil {
+		return nil, errors.Wrap(err, "error creating ticket")
+	}
+	c.InvalidateEventCache(eventID)
+	return &resp.Ticket, nil
+}
+
+// UpdateTicket changes the given details of the ticket type with the given ID, returning the
+// updated ticket. The same validation as CreateTicket applies to the fields which are set.
+func (c *Client) UpdateTicket(ctx context.Context, ticketID int, req UpdateTicketRequest) (*Ticket, error) {
+	if err := validateTicketDetails(req.Price, req.Quantity, req.SaleStartTime, req.SaleEndTime); err != nil {
+		return nil, err
+	}
+	data := new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(req); err != nil {
+		return nil, errors.Wrap(err, "error encoding ticket")
+	}
+	resp := ticketResponse{}
+	if err := c.patch(ctx, c.url(ticketPath, ticketID), data, true, &resp); err != nil {
+		return nil, errors.Wrap(err, "error updating ticket")
+	}
+	c.invalidateTicketEvent(resp.EventID)
+	return &resp.Ticket, nil
+}
+
+// DeleteTicket removes the ticket type with the given ID from its event.
+// A *ForbiddenError will be returned if the user is not the event's organiser.
+func (c *Client) DeleteTicket(ctx context.Context, ticketID int) error {
+	resp := ticketResponse{}
+	if err := c.del(ctx, c.url(ticketPath, ticketID), true, &resp); err != nil {
+		return errors.Wrap(err, "error deleting ticket")
+	}
+	c.invalidateTicketEvent(resp.EventID)
+	return nil
+}
@@ -0,0 +1,240 @@
+package fixr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestGetEventOrganizer(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/event/1/organiser" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id": 5, "name": "Fabric", "website": "https://fabriclondon.com"}`)
+	})
+	o, err := c.GetEventOrganizer(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.ID != 5 || o.Name != "Fabric" || o.Website != "https://fabriclondon.com" {
+		t.Errorf("unexpected organiser: %+v\n", o)
+	}
+	notFoundErr := new(NotFoundError)
+	if _, err := c.GetEventOrganizer(context.Background(), 2); !errors.As(err, &notFoundErr) {
+		t.Errorf("expected *NotFoundError; got %v\n", err)
+	}
+}
+
+func TestFollowOrganizer(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Token abc" {
+			t.Errorf("expected %s; got %s\n", "Token abc", auth)
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path != "/organiser/5/follow" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	authErr := new(AuthError)
+	if err := c.FollowOrganizer(context.Background(), 5); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+	if err := c.UnfollowOrganizer(context.Background(), 5); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+	if len(requests) > 0 {
+		t.Errorf("expected no requests without authentication; got %v\n", requests)
+	}
+	c.AuthToken = "abc"
+	if err := c.FollowOrganizer(context.Background(), 5); err != nil {
+		t.Error(err)
+	}
+	if err := c.UnfollowOrganizer(context.Background(), 5); err != nil {
+		t.Error(err)
+	}
+	notFoundErr := new(NotFoundError)
+	if err := c.FollowOrganizer(context.Background(), 6); !errors.As(err, &notFoundErr) {
+		t.Errorf("expected *NotFoundError; got %v\n", err)
+	}
+	if expected := []string{"POST /organiser/5/follow", "DELETE /organiser/5/follow", "POST /organiser/6/follow"}; fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected %v; got %v\n", expected, requests)
+	}
+}
+
+func TestGetEventRevenue(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/event/1/revenue" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"currency": "GBP", "total_revenue": 450.0, "total_booking_fees": 45.0, "platform_fees": 22.5,
+			"ticket_breakdown": [{"ticket_id": 391220, "name": "Standard Entry", "quantity_sold": 10, "gross_revenue": 450.0}]}`)
+	})
+	c.AuthToken = "abc"
+	report, err := c.GetEventRevenue(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Currency != "GBP" || report.TotalNet() != 427.5 || len(report.TicketBreakdown) != 1 || report.TicketBreakdown[0].QuantitySold != 10 {
+		t.Errorf("unexpected report: %+v\n", report)
+	}
+	forbidden := new(ForbiddenError)
+	if _, err := c.GetEventRevenue(context.Background(), 2); !errors.As(err, &forbidden) {
+		t.Errorf("expected *ForbiddenError; got %v\n", err)
+	}
+}
+
+func TestCreateEvent(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var pl map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&pl)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /event":
+			if pl["name"] != "Summer Ball" || pl["venue_id"] != 2.0 || pl["is_public"] != true {
+				t.Errorf("unexpected payload: %v\n", pl)
+			}
+			fmt.Fprint(w, `{"id": 1, "name": "Summer Ball"}`)
+		case "PATCH /event/1":
+			if len(pl) != 1 || pl["minimum_age"] != 21.0 {
+				t.Errorf("expected only minimum_age to be updated; got %v\n", pl)
+			}
+			fmt.Fprint(w, `{"id": 1, "name": "Summer Ball", "minimum_age": 21}`)
+		default:
+			t.Errorf("unexpected request: %s %s\n", r.Method, r.URL.Path)
+		}
+	})
+	c.AuthToken = "abc"
+	start := time.Now().Add(24 * time.Hour)
+	event, err := c.CreateEvent(context.Background(), CreateEventRequest{Name: "Summer Ball", StartTime: start, EndTime: start.Add(6 * time.Hour), VenueID: 2, IsPublic: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != 1 {
+		t.Errorf("expected %d; got %d\n", 1, event.ID)
+	}
+	minAge := 21
+	if event, err = c.UpdateEvent(context.Background(), 1, UpdateEventRequest{MinAge: &minAge}); err != nil {
+		t.Fatal(err)
+	}
+	if event.MinAge != 21 {
+		t.Errorf("expected %d; got %d\n", 21, event.MinAge)
+	}
+
+	validation := new(ValidationError)
+	if _, err := c.CreateEvent(context.Background(), CreateEventRequest{StartTime: start}); !errors.As(err, &validation) || validation.Field != "name" {
+		t.Errorf("expected *ValidationError for name; got %v\n", err)
+	}
+	past := time.Now().Add(-time.Hour)
+	if _, err := c.UpdateEvent(context.Background(), 1, UpdateEventRequest{StartTime: &past}); !errors.As(err, &validation) || validation.Field != "start_time" {
+		t.Errorf("expected *ValidationError for start_time; got %v\n", err)
+	}
+}
+
+func TestTicketCRUD(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var pl map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&pl)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /event/1/tickets":
+			if pl["name"] != "Early Bird" || pl["quantity"] != 100.0 || pl["sale_start_time"] != nil {
+				t.Errorf("unexpected payload: %v\n", pl)
+			}
+			fmt.Fprint(w, `{"id": 2, "name": "Early Bird", "price": 10.0}`)
+		case "PATCH /ticket/2":
+			if len(pl) != 1 || pl["price"] != 12.5 {
+				t.Errorf("expected only price to be updated; got %v\n", pl)
+			}
+			fmt.Fprint(w, `{"id": 2, "name": "Early Bird", "price": 12.5}`)
+		case "DELETE /ticket/2":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+	c.AuthToken = "abc"
+	ctx := context.Background()
+	ticket, err := c.CreateTicket(ctx, 1, CreateTicketRequest{Name: "Early Bird", Currency: "GBP", Price: 10, Quantity: 100, MaxPerUser: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.ID != 2 || ticket.Price != 10 {
+		t.Errorf("unexpected ticket: %+v\n", ticket)
+	}
+	price := 12.5
+	if ticket, err = c.UpdateTicket(ctx, 2, UpdateTicketRequest{Price: &price}); err != nil {
+		t.Fatal(err)
+	}
+	if ticket.Price != 12.5 {
+		t.Errorf("expected %.2f; got %.2f\n", 12.5, ticket.Price)
+	}
+	if err := c.DeleteTicket(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	forbidden := new(ForbiddenError)
+	if err := c.DeleteTicket(ctx, 3); !errors.As(err, &forbidden) {
+		t.Errorf("expected *ForbiddenError; got %v\n", err)
+	}
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	for field, req := range map[string]CreateTicketRequest{
+		"price":         {Price: -1, Quantity: 1},
+		"quantity":      {Quantity: 0},
+		"sale_end_time": {Quantity: 1, SaleStartTime: &now, SaleEndTime: &past},
+	} {
+		validation := new(ValidationError)
+		if _, err := c.CreateTicket(ctx, 1, req); !errors.As(err, &validation) || validation.Field != field {
+			t.Errorf("expected *ValidationError for %s; got %v\n", field, err)
+		}
+	}
+}
+
+func TestTicketChangesInvalidateEventCache(t *testing.T) {
+	requests := map[string]int{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path]++
+		switch r.Method + " " + r.URL.Path {
+		case "GET /event/1":
+			fmt.Fprint(w, `{"id": 1}`)
+		case "GET /event/5":
+			fmt.Fprint(w, `{"id": 5}`)
+		case "PATCH /ticket/2":
+			fmt.Fprint(w, `{"id": 2, "event_id": 1}`)
+		case "DELETE /ticket/2":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}, WithEventCacheTTL(time.Minute))
+	c.AuthToken = "abc"
+	ctx := context.Background()
+	fetch := func() {
+		for _, id := range []int{1, 5} {
+			if _, err := c.EventWithContext(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	fetch()
+	price := 12.5
+	if _, err := c.UpdateTicket(ctx, 2, UpdateTicketRequest{Price: &price}); err != nil {
+		t.Fatal(err)
+	}
+	fetch()
+	if requests["GET /event/1"] != 2 || requests["GET /event/5"] != 1 {
+		t.Errorf("expected only the ticket's event to be evicted; got %v\n", requests)
+	}
+	if err := c.DeleteTicket(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	fetch()
+	if requests["GET /event/1"] != 3 || requests["GET /event/5"] != 2 {
+		t.Errorf("expected every event to be evicted; got %v\n", requests)
+	}
+}
@@ -0,0 +1,198 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PageIterator iterates over the pages of a paginated API response, fetching each page on demand:
+//
+//	it := c.IterateBookings(fixr.BookingFilter{})
+//	for it.Next(ctx) {
+//		for _, b := range it.Value() {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PageIterator[T any] struct {
+	fetch   func(ctx context.Context, page int) ([]T, bool, error)
+	page    int
+	items   []T
+	hasMore bool
+	err     error
+}
+
+// newPageIterator returns a *PageIterator which starts at page 1. fetch returns the items
+// on the given page and whether there are any more pages.
+func newPageIterator[T any](fetch func(ctx context.Context, page int) ([]T, bool, error)) *PageIterator[T] {
+	return &PageIterator[T]{fetch: fetch, hasMore: true}
+}
+
+// Next fetches the next page, returning false when there are no more pages or an error is encountered.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || !it.hasMore {
+		return false
+	}
+	it.page++
+	it.items, it.hasMore, it.err = it.fetch(ctx, it.page)
+	if it.err != nil {
+		it.items = nil
+		return false
+	}
+	return true
+}
+
+// Value returns the items on the current page.
+func (it *PageIterator[T]) Value() []T {
+	return it.items
+}
+
+// Page returns the number of the current page (starting at 1).
+func (it *PageIterator[T]) Page() int {
+	return it.page
+}
+
+// Err returns the error encountered by Next, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// IterateEvents returns a *PageIterator over the events matching filter (see SearchEvents).
+// Iteration starts at filter.Page, if set.
+func (c *Client) IterateEvents(filter EventFilter) *PageIterator[Event] {
+	offset := filter.Page
+	if offset > 0 {
+		offset--
+	}
+	return newPageIterator(func(ctx context.Context, page int) ([]Event, bool, error) {
+		filter.Page = offset + page
+		list, err := c.SearchEventsWithContext(ctx, "", filter)
+		if err != nil {
+			return nil, false, err
+		}
+		return list.Items, list.HasNextPage, nil
+	})
+}
+
+// BookingFilter configures the iteration of the user's bookings.
+type BookingFilter struct {
+	// PageSize is the number of bookings fetched per page (50 if not positive).
+	PageSize int
+}
+
+// IterateBookings returns a *PageIterator over the user's bookings (see GetBookingHistory).
+func (c *Client) IterateBookings(filter BookingFilter) *PageIterator[Booking] {
+	if filter.PageSize <= 0 {
+		filter.PageSize = historyPageSize
+	}
+	return newPageIterator(func(ctx context.Context, page int) ([]Booking, bool, error) {
+		list, err := c.GetBookingHistoryWithContext(ctx, page, filter.PageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		return list.Items, list.HasNextPage, nil
+	})
+}
+
+// linkSetter is implemented by responses which take their pagination links from the Link
+// header (see decodeJSONResponse).
+type linkSetter interface {
+	setLinks(links map[string]string)
+}
+
+// parseLinkHeader parses an HTTP Link header (e.g. `<https://api.fixr.co/bookings?page=2>;
+// rel="next"`) into a map of URLs by relation.
+func parseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(strings.TrimSpace(link), ";")
+		target := strings.TrimSpace(parts[0])
+		if len(target) < 2 || target[0] != '<' || target[len(target)-1] != '>' {
+			continue
+		}
+		for _, param := range parts[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+				continue
+			}
+			// A link may have several space-separated relations, e.g. rel="next last".
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+				links[strings.ToLower(rel)] = target[1 : len(target)-1]
+			}
+		}
+	}
+	return links
+}
+
+// PaginatedResponse contains a single page of a paginated API response. NextURL and PrevURL
+// are the URLs of the next and previous pages (nil if there are none), taken from either the
+// response's Link header or its body. The next page can be fetched with FetchNextPage.
+type PaginatedResponse[T any] struct {
+	apiError
+	Items   []T
+	NextURL *string
+	PrevURL *string
+}
+
+// UnmarshalJSON decodes either a JSON array of items or an object with the items in results
+// and the links in next and previous.
+func (p *PaginatedResponse[T]) UnmarshalJSON(data []byte) error {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &p.Items)
+	}
+	body := struct {
+		apiError
+		Items []T     `json:"results"`
+		Next  *string `json:"next"`
+		Prev  *string `json:"previous"`
+	}{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+	p.apiError, p.Items, p.NextURL, p.PrevURL = body.apiError, body.Items, body.Next, body.Prev
+	return nil
+}
+
+func (p *PaginatedResponse[T]) setLinks(links map[string]string) {
+	if next, ok := links["next"]; ok {
+		p.NextURL = &next
+	}
+	for _, rel := range []string{"prev", "previous"} {
+		if prev, ok := links[rel]; ok {
+			p.PrevURL = &prev
+		}
+	}
+}
+
+// FetchNextPage fetches the page after prev (see PaginatedResponse), returning nil (and no
+// error) if prev is the last page. As Go methods cannot have type parameters, it is a function
+// rather than a method of Client. The next page must be on the same host as the client's base URL.
+func FetchNextPage[T any](ctx context.Context, c *Client, prev *PaginatedResponse[T]) (*PaginatedResponse[T], error) {
+	if prev == nil || prev.NextURL == nil {
+		return nil, nil
+	}
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base URL")
+	}
+	next, err := base.Parse(*prev.NextURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid next page URL")
+	}
+	// The next page is fetched with the user's auth token, which must not be sent elsewhere.
+	if next.Host != base.Host {
+		return nil, errors.Errorf("next page URL %s is not on %s", next, base.Host)
+	}
+	page := PaginatedResponse[T]{}
+	if err := c.get(ctx, next.String(), true, &page); err != nil {
+		return nil, errors.Wrap(err, "error getting next page")
+	}
+	return &page, nil
+}
@@ -0,0 +1,125 @@
+package fixr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPageIterator(t *testing.T) {
+	it := newPageIterator(func(ctx context.Context, page int) ([]int, bool, error) {
+		return []int{page * 10, page*10 + 1}, page < 3, nil
+	})
+	var result []int
+	for it.Next(context.Background()) {
+		result = append(result, it.Value()...)
+	}
+	if it.Err() != nil || len(result) != 6 || result[5] != 31 {
+		t.Errorf("unexpected result: %v (%v)\n", result, it.Err())
+	}
+}
+
+func TestPageIteratorError(t *testing.T) {
+	expected := errors.New("failed")
+	it := newPageIterator(func(ctx context.Context, page int) ([]int, bool, error) {
+		if page == 2 {
+			return nil, false, expected
+		}
+		return []int{page}, true, nil
+	})
+	pages := 0
+	for it.Next(context.Background()) {
+		pages++
+	}
+	if pages != 1 || it.Err() != expected {
+		t.Errorf("expected %d page and %v; got %d and %v\n", 1, expected, pages, it.Err())
+	}
+}
+
+func TestIterateEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		next := "null"
+		if page == "2" {
+			next = `"/events/search?page=3"`
+		}
+		fmt.Fprintf(w, `{"next": %s, "results": [{"id": %s}]}`, next, page)
+	})
+	it := c.IterateEvents(EventFilter{City: "London", Page: 2})
+	var ids []int
+	for it.Next(context.Background()) {
+		for _, e := range it.Value() {
+			ids = append(ids, e.ID)
+		}
+	}
+	if it.Err() != nil || len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("unexpected result: %v (%v)\n", ids, it.Err())
+	}
+}
+
+func TestParseLinkHeader(t *testing.T) {
+	links := parseLinkHeader(`<https://api.fixr.co/bookings?page=3>; rel="next", <https://api.fixr.co/bookings?page=1>; rel="prev first", <bad>rel=last, invalid`)
+	expected := map[string]string{
+		"next":  "https://api.fixr.co/bookings?page=3",
+		"prev":  "https://api.fixr.co/bookings?page=1",
+		"first": "https://api.fixr.co/bookings?page=1",
+	}
+	if len(links) != len(expected) {
+		t.Errorf("expected %v; got %v\n", expected, links)
+	}
+	for rel, u := range expected {
+		if links[rel] != u {
+			t.Errorf("expected %s; got %s (%s)\n", u, links[rel], rel)
+		}
+	}
+	if links := parseLinkHeader(""); len(links) != 0 {
+		t.Errorf("expected no links; got %v\n", links)
+	}
+}
+
+func TestFetchNextPage(t *testing.T) {
+	var base string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/bookings?page=2&page_size=1>; rel="next"`, base))
+			fmt.Fprint(w, `{"results": [{"id": 1}], "count": 3}`)
+		case "2":
+			fmt.Fprint(w, `{"results": [{"id": 2}], "next": "/bookings?page=3&page_size=1", "previous": "/bookings?page=1&page_size=1"}`)
+		default:
+			w.Header().Set("Link", `<https://evil.example.com/bookings?page=4>; rel="next"`)
+			fmt.Fprint(w, `[{"id": 3}]`)
+		}
+	})
+	base = c.baseURL
+	c.AuthToken = "abc"
+	page, err := c.BookingHistoryPage(context.Background(), 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for page != nil {
+		for _, b := range page.Items {
+			ids = append(ids, b.ID)
+		}
+		if page, err = FetchNextPage(context.Background(), c, page); err != nil {
+			break
+		}
+	}
+	if len(ids) != 3 || ids[2] != 3 {
+		t.Errorf("expected bookings %v; got %v\n", []int{1, 2, 3}, ids)
+	}
+	if err == nil {
+		t.Error("expected an error for a next page on another host")
+	}
+	list, err := c.GetBookingHistoryWithContext(context.Background(), 1, 1)
+	if err != nil || !list.HasNextPage || list.TotalCount != 3 {
+		t.Errorf("expected a next page from the Link header; got %+v (%v)\n", list, err)
+	}
+}
@@ -0,0 +1,67 @@
+package fixr
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+func (c *Client) postPassword(ctx context.Context, addr string, pl payload, auth bool) error {
+	data, err := jsonifyPayload(pl)
+	if err != nil {
+		return err
+	}
+	err = c.post(ctx, addr, data, auth, new(apiError))
+	if errorCode(err) == codeWeakPassword {
+		return &ValidationError{Field: "password", Message: asAPIError(err).Message}
+	}
+	return err
+}
+
+// ChangePassword changes the user's password.
+// An *AuthError will be returned if oldPassword is incorrect, and a *ValidationError
+// if newPassword is rejected (e.g. for being too weak).
+func (c *Client) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	if len(newPassword) == 0 {
+		return &ValidationError{Field: "password", Message: "cannot be empty"}
+	}
+	if err := c.requireAuth(); err != nil {
+		return err
+	}
+	pl := payload{
+		"old_password": oldPassword,
+		"new_password": newPassword,
+	}
+	if err := c.postPassword(ctx, c.url(passwordPath), pl, true); err != nil {
+		return errors.Wrap(err, "error changing password")
+	}
+	return nil
+}
+
+// RequestPasswordReset asks FIXR to email a password reset token to the given address.
+// The reset is completed with ResetPassword.
+func (c *Client) RequestPasswordReset(ctx context.Context, email string) error {
+	if len(email) == 0 {
+		return &ValidationError{Field: "email", Message: "cannot be empty"}
+	}
+	if err := c.postPassword(ctx, c.url(resetRequestPath), payload{"email": email}, false); err != nil {
+		return errors.Wrap(err, "error requesting password reset")
+	}
+	return nil
+}
+
+// ResetPassword sets a new password using the token sent by RequestPasswordReset.
+// A *ValidationError will be returned if newPassword is rejected.
+func (c *Client) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	if len(newPassword) == 0 {
+		return &ValidationError{Field: "password", Message: "cannot be empty"}
+	}
+	pl := payload{
+		"token":        resetToken,
+		"new_password": newPassword,
+	}
+	if err := c.postPassword(ctx, c.url(resetPasswordPath), pl, false); err != nil {
+		return errors.Wrap(err, "error resetting password")
+	}
+	return nil
+}
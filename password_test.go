@@ -0,0 +1,47 @@
+package fixr
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestChangePassword(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c.AuthToken = "abc"
+	if err := c.ChangePassword(context.Background(), "old", "new-password"); err != nil {
+		t.Fatal(err)
+	}
+	validationErr := new(ValidationError)
+	if err := c.ChangePassword(context.Background(), "old", ""); !errors.As(err, &validationErr) {
+		t.Errorf("expected *ValidationError; got %v\n", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected %d requests; got %d\n", 1, calls)
+	}
+}
+
+func TestChangePasswordErrors(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusUnauthorized, `{"detail": "wrong password"}`))
+	c.AuthToken = "abc"
+	authErr := new(AuthError)
+	if err := c.ChangePassword(context.Background(), "wrong", "new-password"); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+	for _, status := range []int{http.StatusBadRequest, http.StatusForbidden} {
+		c = newTestClient(t, statusHandler(status, `{"message": "too weak", "code": "password_too_weak"}`))
+		validationErr := new(ValidationError)
+		if err := c.ResetPassword(context.Background(), "token", "1"); !errors.As(err, &validationErr) {
+			t.Errorf("expected *ValidationError; got %v (status: %d)\n", err, status)
+		} else if validationErr.Message != "too weak" {
+			t.Errorf("expected %s; got %s\n", "too weak", validationErr.Message)
+		}
+	}
+}
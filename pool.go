@@ -0,0 +1,126 @@
+package fixr
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// connPool configures the http.Transport created by the client when any of
+// WithMaxIdleConns, WithIdleConnTimeout, WithMaxConnsPerHost, WithProxyURL,
+// WithNoProxy or any of the TLS options (see tls.go) are given.
+type connPool struct {
+	maxIdleConns    int
+	idleConnTimeout time.Duration
+	maxConnsPerHost int
+
+	// proxy is used for every request if set. Otherwise, the proxy is taken from the
+	// environment unless noProxy is set.
+	proxy   *url.URL
+	noProxy bool
+
+	tls *tls.Config
+}
+
+func (p *connPool) transport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if p.maxIdleConns > 0 {
+		t.MaxIdleConns = p.maxIdleConns
+	}
+	if p.idleConnTimeout > 0 {
+		t.IdleConnTimeout = p.idleConnTimeout
+	}
+	if p.maxConnsPerHost > 0 {
+		t.MaxConnsPerHost = p.maxConnsPerHost
+	}
+	switch {
+	case p.proxy != nil:
+		t.Proxy = http.ProxyURL(p.proxy)
+	case p.noProxy:
+		t.Proxy = nil
+	}
+	if p.tls != nil {
+		t.TLSClientConfig = p.tls
+	}
+	return t
+}
+
+func withConnPool(fn func(*connPool) error) ClientOption {
+	return func(c *Client) error {
+		if c.connPool == nil {
+			c.connPool = new(connPool)
+		}
+		return fn(c.connPool)
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle (keep-alive) connections kept by the client
+// (100 by default). It cannot be combined with WithHTTPClient.
+func WithMaxIdleConns(n int) ClientOption {
+	return withConnPool(func(p *connPool) error {
+		if n < 1 {
+			return errors.New("max idle connections must be at least 1")
+		}
+		p.maxIdleConns = n
+		return nil
+	})
+}
+
+// WithIdleConnTimeout sets how long idle connections are kept open (90s by default).
+// It cannot be combined with WithHTTPClient.
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return withConnPool(func(p *connPool) error {
+		if d <= 0 {
+			return errors.New("idle connection timeout must be positive")
+		}
+		p.idleConnTimeout = d
+		return nil
+	})
+}
+
+// WithMaxConnsPerHost limits the number of connections the client opens to FIXR at once,
+// queueing further requests (unlimited by default). It cannot be combined with WithHTTPClient.
+func WithMaxConnsPerHost(n int) ClientOption {
+	return withConnPool(func(p *connPool) error {
+		if n < 1 {
+			return errors.New("max connections per host must be at least 1")
+		}
+		p.maxConnsPerHost = n
+		return nil
+	})
+}
+
+// WithProxyURL sends all requests through the proxy at proxyURL, which must have an http,
+// https or socks5 scheme (e.g. "socks5://localhost:1080"). By default, the proxy is taken from
+// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables. It cannot be combined with
+// WithHTTPClient.
+func WithProxyURL(proxyURL string) ClientOption {
+	return withConnPool(func(p *connPool) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return errors.Wrap(err, "invalid proxy URL")
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return errors.Errorf("invalid proxy URL: unsupported scheme %q", u.Scheme)
+		}
+		if len(u.Host) == 0 {
+			return errors.New("invalid proxy URL: no host given")
+		}
+		p.proxy, p.noProxy = u, false
+		return nil
+	})
+}
+
+// WithNoProxy connects to FIXR directly, ignoring any proxy given by WithProxyURL or the
+// environment. It cannot be combined with WithHTTPClient.
+func WithNoProxy() ClientOption {
+	return withConnPool(func(p *connPool) error {
+		p.proxy, p.noProxy = nil, true
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package fixr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithMaxConnsPerHost(t *testing.T) {
+	var active, peak int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, `{}`)
+	}, WithMaxConnsPerHost(1))
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := c.Event(i); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if peak != 1 {
+		t.Errorf("expected %d concurrent requests; got %d\n", 1, peak)
+	}
+}
+
+func TestConnPoolWithHTTPClient(t *testing.T) {
+	if _, err := New("test@example.com", WithMaxIdleConns(10), WithHTTPClient(new(http.Client))); err == nil {
+		t.Error("expected connection pool options to be rejected with WithHTTPClient")
+	}
+	if _, err := New("test@example.com", WithIdleConnTimeout(time.Second)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		fmt.Fprint(w, `{"id": 1, "tickets": []}`)
+	}))
+	t.Cleanup(proxy.Close)
+	c, err := New("test@example.com", WithBaseURL("http://fixr.invalid"), WithMaxRetries(0), WithProxyURL(proxy.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Event(1); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "http://fixr.invalid/event/1"; proxied != expected {
+		t.Errorf("expected %s; got %s\n", expected, proxied)
+	}
+
+	for _, proxyURL := range []string{"ftp://proxy:21", "http://%zz", "socks5://"} {
+		if _, err := New("test@example.com", WithProxyURL(proxyURL)); err == nil {
+			t.Errorf("expected an error for %s\n", proxyURL)
+		}
+	}
+	if _, err := New("test@example.com", WithProxyURL("socks5://localhost:1080")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithNoProxy(t *testing.T) {
+	c, err := New("test@example.com", WithMaxRetries(0), WithProxyURL("http://proxy.invalid:3128"), WithNoProxy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport; got %T\n", c.httpClient.Transport)
+	}
+	if transport.Proxy != nil {
+		t.Error("expected no proxy")
+	}
+}
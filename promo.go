@@ -0,0 +1,143 @@
+package fixr
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultConcurrency = 5
+
+// PromoOption configures a single call to Promo.
+type PromoOption func(*promoOptions)
+
+type promoOptions struct {
+	timeout time.Duration
+}
+
+// WithPromoTimeout limits the time taken to fetch the promo code to d.
+func WithPromoTimeout(d time.Duration) PromoOption {
+	return func(o *promoOptions) {
+		o.timeout = d
+	}
+}
+
+// IsValid reports whether the promo code exists and can still be redeemed.
+func (p *PromoCode) IsValid() bool {
+	return p != nil && len(p.Code) > 0 && p.Remaining > 0
+}
+
+// DiscountAmount returns the saving (including the booking fee) made by using the promo
+// code to buy a single ticket t. Zero is returned if the promo code costs more than t.
+func (p *PromoCode) DiscountAmount(t *Ticket) float64 {
+	if p == nil || t == nil {
+		return 0
+	}
+	if saving := t.TotalCost() - t.EffectivePrice(p); saving > 0 {
+		return saving
+	}
+	return 0
+}
+
+// DiscountPercent returns DiscountAmount as a fraction (from 0 to 1) of t's TotalCost.
+func (p *PromoCode) DiscountPercent(t *Ticket) float64 {
+	if t == nil || t.TotalCost() <= 0 {
+		return 0
+	}
+	return p.DiscountAmount(t) / t.TotalCost()
+}
+
+// Refresh fetches the promo code again, updating Remaining, Max, Price and BookingFee,
+// which go stale as other users redeem the code. The promo code must have been returned
+// by Promo, PromoCodes or EventPromo.
+func (p *PromoCode) Refresh(ctx context.Context, c *Client) error {
+	var fresh *PromoCode
+	var err error
+	switch {
+	case p.EventID != 0:
+		fresh, err = c.EventPromo(ctx, p.EventID, p.Code)
+	case p.ticketID != 0:
+		fresh, err = c.PromoWithContext(ctx, p.ticketID, p.Code)
+	default:
+		return errors.New("promo code was not fetched from a ticket or event")
+	}
+	if err != nil {
+		return err
+	}
+	p.Remaining, p.Max, p.Price, p.BookingFee = fresh.Remaining, fresh.Max, fresh.Price, fresh.BookingFee
+	return nil
+}
+
+// PromoIsStillValid fetches the promo code p for the ticket with the given ID and reports
+// whether it can still be redeemed. Unlike Refresh, p is left unchanged. False (and no error)
+// is returned if the promo code no longer exists.
+func (c *Client) PromoIsStillValid(ctx context.Context, p *PromoCode, ticketID int) (bool, error) {
+	fresh, err := c.PromoWithContext(ctx, ticketID, p.Code)
+	if err != nil {
+		if notFound := (*NotFoundError)(nil); errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return fresh.IsValid(), nil
+}
+
+// EventPromo returns the promo code for the event with the given ID. Unlike those from Promo,
+// the promo code applies to any ticket for the event, and has its EventID set.
+func (c *Client) EventPromo(ctx context.Context, eventID int, code string) (*PromoCode, error) {
+	promo := PromoCode{}
+	if err := c.get(ctx, c.url(eventPromoPath, eventID, url.PathEscape(code)), true, &promo); err != nil {
+		return nil, errors.Wrap(err, "error getting event promo code")
+	}
+	promo.EventID = eventID
+	return &promo, nil
+}
+
+// PromoCodes looks up several promo codes for a given ticket ID in parallel.
+// The promo codes and errors are returned in the same order as codes; for each
+// code, either the *PromoCode or the error will be nil.
+func (c *Client) PromoCodes(ticketID int, codes []string) ([]*PromoCode, []error) {
+	return c.PromoCodesWithContext(context.Background(), ticketID, codes)
+}
+
+// PromoCodesWithContext is like PromoCodes but uses ctx for the underlying HTTP requests.
+func (c *Client) PromoCodesWithContext(ctx context.Context, ticketID int, codes []string) ([]*PromoCode, []error) {
+	promos, errs := make([]*PromoCode, len(codes)), make([]error, len(codes))
+	parallel(len(codes), c.concurrency, func(i int) {
+		promos[i], errs[i] = c.PromoWithContext(ctx, ticketID, codes[i])
+	})
+	return promos, errs
+}
+
+// ApplyPromoToEvent looks up the promo code for each of the tickets for the event with the given
+// ID in parallel (see WithConcurrency), returning the promo codes by ticket ID. The value is nil
+// for tickets to which the promo code does not apply, or whose lookup failed; an error is only
+// returned if the event could not be fetched or every lookup failed.
+func (c *Client) ApplyPromoToEvent(ctx context.Context, eventID int, code string) (map[int]*PromoCode, error) {
+	event, err := c.EventWithContext(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	promos, errs := make([]*PromoCode, len(event.Tickets)), make([]error, len(event.Tickets))
+	parallel(len(event.Tickets), c.concurrency, func(i int) {
+		promos[i], errs[i] = c.PromoWithContext(ctx, event.Tickets[i].ID, code)
+		// A missing promo code does not apply to the ticket, rather than the lookup failing.
+		if notFound := (*NotFoundError)(nil); errors.As(errs[i], &notFound) {
+			errs[i] = nil
+		}
+	})
+	byTicket := make(map[int]*PromoCode, len(event.Tickets))
+	var failed int
+	for i, t := range event.Tickets {
+		byTicket[t.ID] = promos[i]
+		if errs[i] != nil {
+			failed++
+		}
+	}
+	if failed > 0 && failed == len(event.Tickets) {
+		return nil, errors.Wrap(errs[0], "error applying promo code to event")
+	}
+	return byTicket, nil
+}
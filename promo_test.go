@@ -0,0 +1,181 @@
+package fixr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPromoCodeIsValid(t *testing.T) {
+	for _, test := range []struct {
+		promo    *PromoCode
+		expected bool
+	}{
+		{nil, false},
+		{&PromoCode{Remaining: 1}, false},
+		{&PromoCode{Code: "FREE"}, false},
+		{&PromoCode{Code: "FREE", Remaining: 1}, true},
+	} {
+		if result := test.promo.IsValid(); result != test.expected {
+			t.Errorf("expected %t; got %t (%+v)\n", test.expected, result, test.promo)
+		}
+	}
+}
+
+func TestPromoCodeDiscount(t *testing.T) {
+	ticket := &Ticket{Price: 9, BookingFee: 1}
+	for _, test := range []struct {
+		promo            *PromoCode
+		amount, fraction float64
+	}{
+		{nil, 0, 0},
+		{&PromoCode{Price: 4, BookingFee: 1}, 5, 0.5},
+		{&PromoCode{}, 10, 1},
+		{&PromoCode{Price: 9, BookingFee: 2}, 0, 0},
+	} {
+		if result := test.promo.DiscountAmount(ticket); result != test.amount {
+			t.Errorf("expected %v; got %v (%+v)\n", test.amount, result, test.promo)
+		}
+		if result := test.promo.DiscountPercent(ticket); result != test.fraction {
+			t.Errorf("expected %v; got %v (%+v)\n", test.fraction, result, test.promo)
+		}
+	}
+	if result := (&PromoCode{}).DiscountPercent(&Ticket{}); result != 0 {
+		t.Errorf("expected %v; got %v\n", 0, result)
+	}
+}
+
+func TestPromoCodes(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		code := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if code == "BAD" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"code": "%s", "remaining": 1}`, code)
+	}, WithConcurrency(2))
+	c.AuthToken = "abc"
+	codes := []string{"A", "BAD", "C", "D"}
+	promos, errs := c.PromoCodes(1, codes)
+	for i, code := range codes {
+		if code == "BAD" {
+			if errs[i] == nil || promos[i] != nil {
+				t.Errorf("expected error for %s\n", code)
+			}
+			continue
+		}
+		if errs[i] != nil || promos[i].Code != code {
+			t.Errorf("expected %s; got %v (%v)\n", code, promos[i], errs[i])
+		}
+	}
+}
+
+func TestPromoCodeRefresh(t *testing.T) {
+	remaining := 2
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/promo_code/1/CODE" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"code": "CODE", "price": 5.0, "max_per_user": 2, "remaining": %d}`, remaining)
+	})
+	c.AuthToken = "abc"
+	ctx := context.Background()
+	promo, err := c.Promo(1, "CODE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining = 0
+	if valid, err := c.PromoIsStillValid(ctx, promo, 1); err != nil || valid {
+		t.Errorf("expected promo code to be invalid; got %t (%v)\n", valid, err)
+	}
+	if promo.Remaining != 2 {
+		t.Errorf("expected 2 remaining before refresh; got %d\n", promo.Remaining)
+	}
+	if err := promo.Refresh(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	if promo.Remaining != 0 || promo.Price != 5 {
+		t.Errorf("expected refreshed promo code; got %+v\n", promo)
+	}
+	if valid, err := c.PromoIsStillValid(ctx, promo, 2); err != nil || valid {
+		t.Errorf("expected missing promo code to be invalid; got %t (%v)\n", valid, err)
+	}
+	if err := (&PromoCode{Code: "CODE"}).Refresh(ctx, c); err == nil {
+		t.Error("expected an error refreshing an unfetched promo code")
+	}
+}
+
+func TestEventPromo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/event/1/promo_code/CODE":
+			fmt.Fprint(w, `{"code": "CODE", "price": 5.0, "remaining": 1}`)
+		case "/booking":
+			var pl map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&pl)
+			if pl["event_promo_code"] != "CODE" || pl["promo_code"] != nil {
+				t.Errorf("expected %s; got %v\n", "event_promo_code", pl)
+			}
+			fmt.Fprint(w, `{"id": 1}`)
+		default:
+			t.Errorf("unexpected request: %s\n", r.URL.Path)
+		}
+	})
+	c.AuthToken = "abc"
+	promo, err := c.EventPromo(context.Background(), 1, "CODE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promo.EventID != 1 || promo.Price != 5 {
+		t.Errorf("expected event promo code; got %+v\n", promo)
+	}
+	if err := promo.Refresh(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Book(&Ticket{ID: 2, Max: 1}, 1, promo, WithoutTicketRefresh()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyPromoToEvent(t *testing.T) {
+	fail := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/event/1":
+			fmt.Fprint(w, `{"id": 1, "tickets": [{"id": 10}, {"id": 11}, {"id": 12}]}`)
+		case "/promo_code/10/CODE", "/promo_code/12/CODE":
+			if fail {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprint(w, `{"code": "CODE", "remaining": 1}`)
+		case "/promo_code/11/CODE":
+			if fail {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c.AuthToken = "abc"
+	promos, err := c.ApplyPromoToEvent(context.Background(), 1, "CODE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(promos) != 3 || promos[10] == nil || promos[11] != nil || promos[12] == nil {
+		t.Errorf("expected promo codes for tickets 10 and 12; got %v\n", promos)
+	}
+	fail = true
+	if _, err := c.ApplyPromoToEvent(context.Background(), 1, "CODE"); err == nil {
+		t.Error("expected an error when every lookup fails")
+	}
+	if _, err := c.ApplyPromoToEvent(context.Background(), 2, "CODE"); err == nil {
+		t.Error("expected an error for a missing event")
+	}
+}
@@ -0,0 +1,49 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"image"
+	// Register the formats in which QR codes are served.
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// QRCode returns the raw QR code image (PNG or JPEG) used to enter the event for the
+// booking with the given ID, e.g. for writing directly to a file. The user's auth token is
+// only sent if the image is hosted by the FIXR API (see WithBaseURL).
+func (c *Client) QRCode(ctx context.Context, bookingID int) ([]byte, error) {
+	b, err := c.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.QRCodeURL) == 0 {
+		return nil, errors.New("booking has no QR code")
+	}
+	resp, err := c.download(ctx, b.QRCodeURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading QR code")
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading QR code")
+	}
+	return data, nil
+}
+
+// GenerateQRCodeImage returns the decoded QR code for the booking with the given ID (see QRCode).
+func (c *Client) GenerateQRCodeImage(ctx context.Context, bookingID int) (image.Image, error) {
+	data, err := c.QRCode(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding QR code")
+	}
+	return img, nil
+}
@@ -0,0 +1,54 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGenerateQRCodeImage(t *testing.T) {
+	var serverURL string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token abc" {
+			t.Errorf("expected %s; got %s\n", "Token abc", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/booking/7":
+			fmt.Fprintf(w, `{"id": 7, "qr_code_url": "%s/qr/7.png"}`, serverURL)
+		case "/qr/7.png":
+			png.Encode(w, image.NewGray(image.Rect(0, 0, 4, 4)))
+		default:
+			t.Errorf("unexpected path %s\n", r.URL.Path)
+		}
+	})
+	serverURL = c.baseURL
+	c.AuthToken = "abc"
+	img, err := c.GenerateQRCodeImage(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Dx(); size != 4 {
+		t.Errorf("expected %d; got %d\n", 4, size)
+	}
+}
+
+func TestQRCodeOtherHost(t *testing.T) {
+	qr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); len(auth) > 0 {
+			t.Errorf("expected no Authorization header; got %s\n", auth)
+		}
+		png.Encode(w, image.NewGray(image.Rect(0, 0, 4, 4)))
+	}))
+	t.Cleanup(qr.Close)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": 7, "qr_code_url": "%s/qr/7.png"}`, qr.URL)
+	})
+	c.AuthToken = "abc"
+	if _, err := c.GenerateQRCodeImage(context.Background(), 7); err != nil {
+		t.Error(err)
+	}
+}
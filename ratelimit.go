@@ -0,0 +1,50 @@
+package fixr
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type rateLimitState struct {
+	mu  sync.Mutex
+	err *RateLimitError
+}
+
+func (r *rateLimitState) set(err *RateLimitError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+func (r *rateLimitState) get() *RateLimitError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil || !time.Now().Before(r.err.RetryAfter) {
+		return nil
+	}
+	return r.err
+}
+
+// GetRateLimitStatus returns the most recent *RateLimitError received by the client,
+// or nil if the client is not currently rate limited.
+func (c *Client) GetRateLimitStatus() *RateLimitError {
+	return c.rateLimit.get()
+}
+
+// WaitForRateLimit blocks until the client is no longer rate limited (see GetRateLimitStatus),
+// returning early with the context's error if ctx is done first.
+func (c *Client) WaitForRateLimit(ctx context.Context) error {
+	status := c.GetRateLimitStatus()
+	if status == nil {
+		return nil
+	}
+	timer := time.NewTimer(time.Until(status.RetryAfter))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
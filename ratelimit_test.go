@@ -0,0 +1,40 @@
+package fixr
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait := time.Until(parseRetryAfter("120")); wait <= 110*time.Second || wait > 120*time.Second {
+		t.Errorf("expected ~120s; got %v\n", wait)
+	}
+	expected := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	if result := parseRetryAfter(expected.Format(http.TimeFormat)); !result.Equal(expected) {
+		t.Errorf("expected %v; got %v\n", expected, result)
+	}
+	if result := parseRetryAfter("soon"); !result.IsZero() {
+		t.Errorf("expected zero time; got %v\n", result)
+	}
+}
+
+func TestWaitForRateLimit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	if c.GetRateLimitStatus() != nil {
+		t.Fatal("expected client not to be rate limited")
+	}
+	c.Event(1)
+	if c.GetRateLimitStatus() == nil {
+		t.Fatal("expected client to be rate limited")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.WaitForRateLimit(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected %v; got %v\n", context.DeadlineExceeded, err)
+	}
+}
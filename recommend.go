@@ -0,0 +1,76 @@
+package fixr
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// recommendationCacheTTL is how long recommended events are cached by the client.
+const recommendationCacheTTL = time.Hour
+
+// RecommendationMeta describes how a set of recommendations was produced.
+type RecommendationMeta struct {
+	Algorithm  string  `json:"algorithm"`
+	Confidence float64 `json:"confidence"`
+}
+
+// RecommendationResult contains the events recommended to the user. FallbackToFeatured is
+// set if the user has no booking history, in which case the featured events are returned.
+type RecommendationResult struct {
+	apiError
+	Events             []Event            `json:"results"`
+	FallbackToFeatured bool               `json:"fallback_to_featured"`
+	Meta               RecommendationMeta `json:"meta"`
+}
+
+type recommendationCache struct {
+	mu        sync.Mutex
+	limit     int
+	result    *RecommendationResult
+	fetchedAt time.Time
+}
+
+// clear removes the cached recommendations, such as when the user logs out.
+func (r *recommendationCache) clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result, r.limit, r.fetchedAt = nil, 0, time.Time{}
+}
+
+// GetRecommendedEvents returns up to limit events recommended to the user based on their
+// booking history. Results are cached by the client for an hour.
+// An *AuthError will be returned if the client is not authenticated.
+func (c *Client) GetRecommendedEvents(ctx context.Context, limit int) (*RecommendationResult, error) {
+	if limit < 1 {
+		return nil, &ValidationError{Field: "limit", Message: "must be positive"}
+	}
+	cache := c.recommended
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.result != nil && cache.limit == limit && time.Since(cache.fetchedAt) < recommendationCacheTTL {
+		return copyRecommendations(cache.result), nil
+	}
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	result := RecommendationResult{}
+	if err := c.get(ctx, c.url(recommendPath)+"?"+query.Encode(), true, &result); err != nil {
+		return nil, errors.Wrap(err, "error getting recommended events")
+	}
+	if len(result.Events) > limit {
+		result.Events = result.Events[:limit]
+	}
+	cache.limit, cache.result, cache.fetchedAt = limit, &result, time.Now()
+	return copyRecommendations(&result), nil
+}
+
+// copyRecommendations returns a copy of r which can be modified without altering the cache.
+func copyRecommendations(r *RecommendationResult) *RecommendationResult {
+	result := *r
+	result.Events = append([]Event(nil), r.Events...)
+	return &result
+}
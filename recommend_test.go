@@ -0,0 +1,38 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestGetRecommendedEvents(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if limit := r.URL.Query().Get("limit"); limit != "2" {
+			t.Errorf("expected %s; got %s\n", "2", limit)
+		}
+		fmt.Fprint(w, `{"results": [{"id": 1}, {"id": 2}, {"id": 3}], "fallback_to_featured": true, "meta": {"algorithm": "popular", "confidence": 0.5}}`)
+	})
+	authErr := new(AuthError)
+	if _, err := c.GetRecommendedEvents(context.Background(), 2); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+	c.AuthToken = "abc"
+	for i := 0; i < 2; i++ {
+		result, err := c.GetRecommendedEvents(context.Background(), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Events) != 2 || !result.FallbackToFeatured || result.Meta.Algorithm != "popular" {
+			t.Errorf("expected 2 featured events; got %+v\n", result)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected %d; got %d\n", 1, requests)
+	}
+}
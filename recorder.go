@@ -0,0 +1,147 @@
+package fixr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// cassetteInteraction is a single request and its response, as stored in a cassette file.
+type cassetteInteraction struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// nondeterministicHeaders are omitted from recorded responses, so that recording the same
+// responses twice produces the same cassette.
+var nondeterministicHeaders = []string{"Date"}
+
+// RecorderTransport is an http.RoundTripper which records requests and their responses to a
+// cassette (JSON) file, or plays them back from one, so that tests can run without the live API.
+// Request headers (including Authorization) are never recorded.
+type RecorderTransport struct {
+	mu           sync.Mutex
+	base         http.RoundTripper
+	path         string
+	interactions []cassetteInteraction
+	playback     bool
+}
+
+// NewRecorder returns a *RecorderTransport which makes requests with inner (http.DefaultTransport
+// if nil), writing each one to the cassette at path, which is replaced.
+func NewRecorder(inner http.RoundTripper, cassettePath string) *RecorderTransport {
+	return &RecorderTransport{base: inner, path: cassettePath}
+}
+
+// NewPlayback returns a *RecorderTransport which responds to requests from the cassette at path,
+// without making any. Each request is answered with the next unplayed response recorded for the
+// same method and URL; an error is returned if there are none.
+func NewPlayback(cassettePath string) (*RecorderTransport, error) {
+	data, err := os.ReadFile(cassettePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading cassette")
+	}
+	t := &RecorderTransport{path: cassettePath, playback: true}
+	if err := json.Unmarshal(data, &t.interactions); err != nil {
+		return nil, errors.Wrap(err, "error decoding cassette")
+	}
+	return t, nil
+}
+
+// WithRecorder records every request made by the client to the cassette at path (see NewRecorder).
+func WithRecorder(cassettePath string) ClientOption {
+	return func(c *Client) error {
+		c.innerTransport = func(rt http.RoundTripper) http.RoundTripper {
+			return NewRecorder(rt, cassettePath)
+		}
+		return nil
+	}
+}
+
+// WithPlayback answers every request made by the client from the cassette at path (see NewPlayback).
+func WithPlayback(cassettePath string) ClientOption {
+	return func(c *Client) error {
+		t, err := NewPlayback(cassettePath)
+		if err != nil {
+			return err
+		}
+		c.innerTransport = func(http.RoundTripper) http.RoundTripper {
+			return t
+		}
+		return nil
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RecorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.playback {
+		return t.play(req)
+	}
+	resp, err := orDefaultTransport(t.base).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error recording response")
+	}
+	header := resp.Header.Clone()
+	for _, key := range nondeterministicHeaders {
+		header.Del(key)
+	}
+	if err := t.record(cassetteInteraction{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     header,
+		Body:       body,
+	}); err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (t *RecorderTransport) record(i cassetteInteraction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, i)
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error encoding cassette")
+	}
+	return errors.Wrap(os.WriteFile(t.path, data, 0644), "error writing cassette")
+}
+
+func (t *RecorderTransport) play(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	url := req.URL.String()
+	for n, i := range t.interactions {
+		if i.Method != req.Method || i.URL != url {
+			continue
+		}
+		t.interactions = append(t.interactions[:n:n], t.interactions[n+1:]...)
+		return &http.Response{
+			Status:        http.StatusText(i.StatusCode),
+			StatusCode:    i.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Header,
+			Body:          io.NopCloser(bytes.NewReader(i.Body)),
+			ContentLength: int64(len(i.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, errors.Errorf("no recorded response for %s %s", req.Method, url)
+}
@@ -0,0 +1,47 @@
+package fixr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderPlayback(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	recorder, err := New("test@example.com", WithBaseURL(server.URL), WithMaxRetries(0), WithRecorder(cassette))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorder.Event(1); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Date") {
+		t.Errorf("expected Date header to be stripped from %s\n", data)
+	}
+	player, err := New("test@example.com", WithBaseURL(server.URL), WithMaxRetries(0), WithPlayback(cassette))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := player.Event(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ID != 1 {
+		t.Errorf("expected %d; got %d\n", 1, e.ID)
+	}
+	if _, err := player.Event(1); err == nil {
+		t.Error("expected error once the cassette is exhausted")
+	}
+}
@@ -0,0 +1,39 @@
+package fixr
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// SetRequestIDFunc sets the function used to generate the X-Request-ID header sent with each
+// request, such as DefaultRequestIDFunc, or disables the header if f is nil. The ID of a failed
+// request is given by the RequestID field of the resulting *APIError (or the error embedding it).
+// It must not be called while requests are in progress.
+func (c *Client) SetRequestIDFunc(f func() string) {
+	c.requestIDFunc = f
+}
+
+// DefaultRequestIDFunc returns a random (version 4) UUID. An empty string, for which no
+// X-Request-ID is sent, is returned if random bytes cannot be read.
+func DefaultRequestIDFunc() string {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(randReader, b); err != nil {
+		return ""
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// requestID returns the X-Request-ID sent with the request for resp, or else that of resp.
+func requestID(resp *http.Response) string {
+	if resp.Request != nil {
+		if id := resp.Request.Header.Get(requestIDHeader); len(id) > 0 {
+			return id
+		}
+	}
+	return resp.Header.Get(requestIDHeader)
+}
@@ -0,0 +1,46 @@
+package fixr
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestDefaultRequestIDFunc(t *testing.T) {
+	defer func(r io.Reader) { randReader = r }(randReader)
+	randReader = bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))
+	if id, expected := DefaultRequestIDFunc(), "ffffffff-ffff-4fff-bfff-ffffffffffff"; id != expected {
+		t.Errorf("expected %s; got %s\n", expected, id)
+	}
+	if id := DefaultRequestIDFunc(); len(id) > 0 {
+		t.Errorf("expected no ID from an exhausted reader; got %s\n", id)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var sent []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("X-Request-ID"))
+		w.WriteHeader(http.StatusNotFound)
+	})
+	if _, err := c.Event(1); err == nil {
+		t.Fatal("expected an error")
+	}
+	c.SetRequestIDFunc(DefaultRequestIDFunc)
+	_, err := c.Event(1)
+	notFound := new(NotFoundError)
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected *NotFoundError; got %v\n", err)
+	}
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if len(sent) != 2 || len(sent[0]) > 0 || !uuid.MatchString(sent[1]) {
+		t.Fatalf("expected no ID and then a UUID; got %q\n", sent)
+	}
+	if notFound.RequestID != sent[1] {
+		t.Errorf("expected %s; got %s\n", sent[1], notFound.RequestID)
+	}
+}
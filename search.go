@@ -0,0 +1,130 @@
+package fixr
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EventFilter narrows the results of SearchEvents. Zero-valued fields are ignored.
+type EventFilter struct {
+	Name     string
+	City     string
+	Category string
+	// CategoryID is the ID of a Category returned by GetEventCategories.
+	CategoryID int
+	DateFrom   time.Time
+	DateTo     time.Time
+	Lat        float64
+	Lng        float64
+	RadiusKm   int
+	Page       int
+	PageSize   int
+}
+
+func (f EventFilter) hasLocation() bool {
+	return len(f.City) > 0 || f.Lat != 0 || f.Lng != 0
+}
+
+func (f EventFilter) values() url.Values {
+	v := url.Values{}
+	for key, value := range map[string]string{
+		"name":     f.Name,
+		"city":     f.City,
+		"category": f.Category,
+	} {
+		if len(value) > 0 {
+			v.Set(key, value)
+		}
+	}
+	for key, value := range map[string]time.Time{
+		"date_from": f.DateFrom,
+		"date_to":   f.DateTo,
+	} {
+		if !value.IsZero() {
+			v.Set(key, value.Format(time.RFC3339))
+		}
+	}
+	if f.Lat != 0 || f.Lng != 0 {
+		v.Set("lat", strconv.FormatFloat(f.Lat, 'f', -1, 64))
+		v.Set("lng", strconv.FormatFloat(f.Lng, 'f', -1, 64))
+	}
+	for key, value := range map[string]int{
+		"category_id": f.CategoryID,
+		"radius_km":   f.RadiusKm,
+		"page":        f.Page,
+		"page_size":   f.PageSize,
+	} {
+		if value > 0 {
+			v.Set(key, strconv.Itoa(value))
+		}
+	}
+	return v
+}
+
+// EventList contains a single page of events.
+type EventList struct {
+	apiError
+	Items       []Event `json:"results"`
+	TotalCount  int     `json:"count"`
+	HasNextPage bool    `json:"-"`
+}
+
+type eventPage struct {
+	EventList
+	Next *string `json:"next"`
+}
+
+// SearchEvents returns the events matching query and filter.
+// Either query, filter.Name or a location (City, or Lat and Lng) must be given.
+// An error will be returned if one is encountered.
+func (c *Client) SearchEvents(query string, filter EventFilter) (*EventList, error) {
+	return c.SearchEventsWithContext(context.Background(), query, filter)
+}
+
+// SearchEventsWithContext is like SearchEvents but uses ctx for the underlying HTTP request.
+func (c *Client) SearchEventsWithContext(ctx context.Context, query string, filter EventFilter) (*EventList, error) {
+	if len(query) == 0 && len(filter.Name) == 0 && !filter.hasLocation() {
+		return nil, errors.New("a search query or location is required")
+	}
+	values := filter.values()
+	if len(query) > 0 {
+		values.Set("q", query)
+	}
+	resp := eventPage{}
+	if err := c.get(ctx, c.url(searchPath)+"?"+values.Encode(), false, &resp); err != nil {
+		return nil, errors.Wrap(err, "error searching events")
+	}
+	resp.HasNextPage = resp.Next != nil
+	return &resp.EventList, nil
+}
+
+// GetFeaturedEvents returns the events currently featured by FIXR.
+func (c *Client) GetFeaturedEvents(ctx context.Context) ([]Event, error) {
+	events := []Event{}
+	if err := c.get(ctx, c.url(featuredPath), false, &listResponse{items: &events}); err != nil {
+		return nil, errors.Wrap(err, "error getting featured events")
+	}
+	return events, nil
+}
+
+// GetUpcomingEvents returns the next events to take place, ordered by start time.
+// Only the City, Category, CategoryID, DateFrom, Page and PageSize fields of filter are used.
+func (c *Client) GetUpcomingEvents(ctx context.Context, filter EventFilter) ([]Event, error) {
+	upcoming := EventFilter{
+		City:       filter.City,
+		Category:   filter.Category,
+		CategoryID: filter.CategoryID,
+		DateFrom:   filter.DateFrom,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+	}
+	resp := eventPage{}
+	if err := c.get(ctx, c.url(upcomingPath)+"?"+upcoming.values().Encode(), false, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting upcoming events")
+	}
+	return resp.Items, nil
+}
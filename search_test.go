@@ -0,0 +1,87 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestEventFilterValues(t *testing.T) {
+	filter := EventFilter{
+		City:     "London",
+		DateFrom: time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC),
+		Lat:      51.5,
+		Lng:      -0.12,
+		Page:     2,
+	}
+	result, expected := filter.values().Encode(), "city=London&date_from=2017-06-01T00%3A00%3A00Z&lat=51.5&lng=-0.12&page=2"
+	if result != expected {
+		t.Errorf("expected %s; got %s\n", expected, result)
+	}
+}
+
+func TestSearchEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "party" {
+			t.Errorf("expected %s; got %s\n", "party", q)
+		}
+		fmt.Fprint(w, `{"count": 3, "next": "/events/search?page=2", "results": [{"id": 1}, {"id": 2}]}`)
+	})
+	list, err := c.SearchEvents("party", EventFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 || list.TotalCount != 3 || !list.HasNextPage {
+		t.Errorf("unexpected result: %+v\n", list)
+	}
+}
+
+func TestSearchEventsRequiresQueryOrLocation(t *testing.T) {
+	if _, err := NewClient("").SearchEvents("", EventFilter{Category: "music"}); err == nil {
+		t.Error("expected search without query or location to fail")
+	}
+}
+
+func serveFile(t *testing.T, path string) http.HandlerFunc {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}
+}
+
+func TestGetFeaturedEvents(t *testing.T) {
+	c := newTestClient(t, serveFile(t, "testdata/featured_events.json"))
+	events, err := c.GetFeaturedEvents(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected %d events; got %d\n", 2, len(events))
+	}
+	e := events[0]
+	if e.Name != "Summer Ball 2017" || e.Venue.City != "London" || len(e.Tickets) != 2 || !e.Tickets[1].SoldOut {
+		t.Errorf("unexpected event: %+v\n", e)
+	}
+}
+
+func TestGetUpcomingEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("city") != "London" || r.URL.Query().Get("lat") != "" {
+			t.Errorf("unexpected query: %s\n", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"count": 1, "next": null, "results": [{"id": 1}]}`)
+	})
+	events, err := c.GetUpcomingEvents(context.Background(), EventFilter{City: "London", Lat: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != 1 {
+		t.Errorf("unexpected events: %+v\n", events)
+	}
+}
@@ -0,0 +1,51 @@
+package fixr
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+type session struct {
+	Email      string      `json:"email"`
+	AuthToken  string      `json:"auth_token"`
+	FirstName  string      `json:"first_name"`
+	LastName   string      `json:"last_name"`
+	StripeUser *stripeUser `json:"stripe_user"`
+}
+
+// SaveSession writes the client's authentication state to w as JSON, so that it can
+// later be restored with LoadSession rather than logging on again.
+// The session contains the auth token and should be stored securely.
+func (c *Client) SaveSession(w io.Writer) error {
+	if err := c.requireAuth(); err != nil {
+		return errors.Wrap(err, "error saving session")
+	}
+	s := session{
+		Email:      c.Email,
+		AuthToken:  c.AuthToken,
+		FirstName:  c.FirstName,
+		LastName:   c.LastName,
+		StripeUser: c.StripeUser,
+	}
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		return errors.Wrap(err, "error saving session")
+	}
+	return nil
+}
+
+// LoadSession restores the authentication state written by SaveSession from r.
+func (c *Client) LoadSession(r io.Reader) error {
+	s := session{}
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return errors.Wrap(err, "error loading session")
+	}
+	if len(s.AuthToken) == 0 {
+		return errors.New("error loading session: no auth token")
+	}
+	c.Email, c.AuthToken = s.Email, s.AuthToken
+	c.FirstName, c.LastName = s.FirstName, s.LastName
+	c.StripeUser = s.StripeUser
+	return nil
+}
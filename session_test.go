@@ -0,0 +1,36 @@
+package fixr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	c := NewClient("test@example.com")
+	c.AuthToken, c.FirstName = "abc", "Test"
+	c.StripeUser = &stripeUser{UserID: "cus_1"}
+	buf := new(bytes.Buffer)
+	if err := c.SaveSession(buf); err != nil {
+		t.Fatal(err)
+	}
+	restored := NewClient("")
+	if err := restored.LoadSession(buf); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Email != c.Email || restored.AuthToken != c.AuthToken || restored.FirstName != c.FirstName {
+		t.Errorf("expected %+v; got %+v\n", c, restored)
+	}
+	if restored.StripeUser == nil || restored.StripeUser.UserID != "cus_1" {
+		t.Errorf("expected %s; got %+v\n", "cus_1", restored.StripeUser)
+	}
+}
+
+func TestSessionWithoutToken(t *testing.T) {
+	if err := NewClient("test@example.com").SaveSession(new(bytes.Buffer)); err == nil {
+		t.Error("expected saving an unauthenticated session to fail")
+	}
+	if err := NewClient("").LoadSession(strings.NewReader(`{"email": "test@example.com"}`)); err == nil {
+		t.Error("expected loading a session without a token to fail")
+	}
+}
@@ -2,7 +2,16 @@ package fixr
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -10,6 +19,8 @@ import (
 const (
 	key string = "pk_live_Jc9zYhZyq3a4JviHWZFBFRdp"
 	ua  string = "stripe.js/604c5e8"
+
+	stripeCardError = "card_error"
 )
 
 type stripeError struct {
@@ -59,11 +70,16 @@ type tokenRequest struct {
 
 // HasCard checks for the existence of a saved card in the user's FIXR account.
 // The result and an error, if encountered, will be returned.
-func (c *Client) HasCard() (existingCards bool, returnErr error) {
+func (c *Client) HasCard() (bool, error) {
+	return c.HasCardWithContext(context.Background())
+}
+
+// HasCardWithContext is like HasCard but uses ctx for the underlying HTTP request.
+func (c *Client) HasCardWithContext(ctx context.Context) (existingCards bool, returnErr error) {
 	if c.StripeUser == nil {
 		return
 	}
-	if err := c.get(meURL, true, c); err != nil {
+	if err := c.Me(ctx); err != nil {
 		returnErr = errors.Wrap(err, "error updating stripe details")
 	}
 	existingCards = len(c.StripeUser.Cards) != 0
@@ -83,31 +99,204 @@ func encodeAddCardPayload(pl payload) (*bytes.Buffer, error) {
 // AddCard saves a card to the user's FIXR account, given the card details.
 // An error will be returned if encountered
 func (c *Client) AddCard(num, month, year, cvc, zip string) error {
-	token := token{}
-	pl := payload{
-		"payment_user_agent": ua,
-		"key":                key,
-		"card[number]":       num,
-		"card[exp_month]":    month,
-		"card[exp_year]":     year,
-		"card[cvc]":          cvc,
-		"card[address_zip]":  zip,
+	return c.AddCardWithContext(context.Background(), num, month, year, cvc, zip)
+}
+
+// AddCardWithContext is like AddCard but uses ctx for the underlying HTTP requests.
+func (c *Client) AddCardWithContext(ctx context.Context, num, month, year, cvc, zip string) error {
+	tok, err := c.createToken(ctx, payload{
+		"card[number]":      num,
+		"card[exp_month]":   month,
+		"card[exp_year]":    year,
+		"card[cvc]":         cvc,
+		"card[address_zip]": zip,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error retrieving tokens")
 	}
+	if _, err := c.AddSavedCard(ctx, tok); err != nil {
+		return errors.Wrap(err, "error sending tokens")
+	}
+	return nil
+}
+
+// createToken exchanges the card details in pl for a Stripe token.
+func (c *Client) createToken(ctx context.Context, pl payload) (string, error) {
+	pl["payment_user_agent"] = ua
+	pl["key"] = key
 	data, err := encodeAddCardPayload(pl)
 	if err != nil {
-		return err
+		return "", err
 	}
-	if err := c.post(cardURL, data, false, &token); err != nil {
-		return errors.Wrap(err, "error retrieving tokens")
+	token := token{}
+	if err := c.post(ctx, cardURL, data, false, &token); err != nil {
+		return "", err
+	}
+	return token.Token, nil
+}
+
+var (
+	cardNumberPattern = regexp.MustCompile(`^[0-9]{13,19}$`)
+	cvcPattern        = regexp.MustCompile(`^[0-9]{3,4}$`)
+)
+
+// validateCard checks the card details given to TokenizeCard before they are sent to Stripe.
+func validateCard(number, expMonth, expYear, cvc string) error {
+	if !cardNumberPattern.MatchString(number) {
+		return &CardValidationError{Field: "number", Message: "must be 13 to 19 digits"}
+	}
+	month, err := strconv.Atoi(expMonth)
+	if err != nil || month < 1 || month > 12 {
+		return &CardValidationError{Field: "exp_month", Message: "must be from 1 to 12"}
+	}
+	year, err := strconv.Atoi(expYear)
+	if err != nil || len(expYear) != 2 && len(expYear) != 4 {
+		return &CardValidationError{Field: "exp_year", Message: "must be 2 or 4 digits"}
+	}
+	if year < 100 {
+		year += 2000
+	}
+	now := time.Now()
+	if year < now.Year() || year == now.Year() && time.Month(month) < now.Month() {
+		return &CardValidationError{Field: "exp_year", Message: "card has expired"}
+	}
+	if !cvcPattern.MatchString(cvc) {
+		return &CardValidationError{Field: "cvc", Message: "must be 3 or 4 digits"}
+	}
+	return nil
+}
+
+// TokenizeCard exchanges card details for a Stripe token, which can be saved with AddSavedCard.
+// Spaces in number are ignored. A *CardValidationError will be returned if any of the details
+// are invalid, whether checked locally or rejected by Stripe.
+func (c *Client) TokenizeCard(ctx context.Context, number, expMonth, expYear, cvc string) (string, error) {
+	number = strings.ReplaceAll(number, " ", "")
+	if err := validateCard(number, expMonth, expYear, cvc); err != nil {
+		return "", err
+	}
+	tok, err := c.createToken(ctx, payload{
+		"card[number]":    number,
+		"card[exp_month]": expMonth,
+		"card[exp_year]":  expYear,
+		"card[cvc]":       cvc,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "error tokenizing card")
+	}
+	return tok, nil
+}
+
+// stripeStatusError builds the error corresponding to an unsuccessful response from Stripe,
+// whose errors are formatted differently to FIXR's.
+func stripeStatusError(resp *http.Response) error {
+	body := token{}
+	// As with statusError, an undecodable body still yields a typed error.
+	json.NewDecoder(resp.Body).Decode(&body)
+	if body.Error == nil {
+		return &APIError{StatusCode: resp.StatusCode, RequestID: requestID(resp)}
 	}
+	if body.Error.Type == stripeCardError {
+		return &CardValidationError{Field: strings.TrimSuffix(strings.TrimPrefix(body.Error.Param, "card["), "]"), Message: body.Error.Message, RequestID: requestID(resp)}
+	}
+	return &APIError{StatusCode: resp.StatusCode, Code: body.Error.Code, Message: body.Error.Message, RequestID: requestID(resp)}
+}
+
+// SavedCard contains the details of a payment card saved to the user's FIXR account.
+type SavedCard struct {
+	ID       string
+	Last4    string
+	Brand    string
+	ExpMonth int
+	ExpYear  int
+}
+
+func (s stripeCard) saved() SavedCard {
+	return SavedCard{
+		ID:       s.CardID,
+		Last4:    s.Last4,
+		Brand:    s.Brand,
+		ExpMonth: s.ExpiryMonth,
+		ExpYear:  s.ExpiryYear,
+	}
+}
+
+// ListSavedCards returns the payment cards saved to the user's FIXR account.
+func (c *Client) ListSavedCards(ctx context.Context) ([]SavedCard, error) {
 	tokenReq := tokenRequest{}
-	tokenData, err := jsonifyPayload(payload{"token": &token.Token})
+	if err := c.get(ctx, c.url(tokenPath), true, &tokenReq); err != nil {
+		return nil, errors.Wrap(err, "error listing cards")
+	}
+	c.StripeUser = tokenReq.User
+	var cards []SavedCard
+	if tokenReq.User != nil {
+		for _, card := range tokenReq.User.Cards {
+			cards = append(cards, card.saved())
+		}
+	}
+	return cards, nil
+}
+
+// AddSavedCard saves a card to the user's FIXR account, given a Stripe token for it
+// (see AddCard to save a card from its details). The saved card is returned.
+func (c *Client) AddSavedCard(ctx context.Context, stripeToken string) (*SavedCard, error) {
+	tokenReq := tokenRequest{}
+	tokenData, err := jsonifyPayload(payload{"token": stripeToken})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if err := c.post(tokenURL, tokenData, true, &tokenReq); err != nil {
-		return errors.Wrap(err, "error sending tokens")
+	if err := c.post(ctx, c.url(tokenPath), tokenData, true, &tokenReq); err != nil {
+		return nil, errors.Wrap(err, "error adding card")
 	}
 	c.StripeUser = tokenReq.User
+	if tokenReq.User == nil || len(tokenReq.User.Cards) == 0 {
+		return nil, errors.New("error adding card: no cards returned")
+	}
+	// The newly added card is listed last
+	card := tokenReq.User.Cards[len(tokenReq.User.Cards)-1].saved()
+	return &card, nil
+}
+
+// DeleteCard removes the card with the given ID from the user's FIXR account.
+func (c *Client) DeleteCard(ctx context.Context, cardID string) error {
+	if err := c.del(ctx, c.url(cardPath, url.PathEscape(cardID)), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error deleting card")
+	}
 	return nil
 }
+
+// SetDefaultCard makes the card with the given ID the default payment method.
+func (c *Client) SetDefaultCard(ctx context.Context, cardID string) error {
+	if err := c.post(ctx, c.url(defaultPath, url.PathEscape(cardID)), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error setting default card")
+	}
+	return nil
+}
+
+type stripeKeyCache struct {
+	mu  sync.Mutex
+	key string
+}
+
+type stripeConfigResponse struct {
+	apiError
+	PublishableKey string `json:"publishable_key"`
+}
+
+// GetStripePublishableKey returns FIXR's Stripe publishable key, for initialising Stripe.js
+// or a mobile SDK. The key is cached by the client once fetched, as it does not change.
+func (c *Client) GetStripePublishableKey(ctx context.Context) (string, error) {
+	c.stripeKey.mu.Lock()
+	defer c.stripeKey.mu.Unlock()
+	if len(c.stripeKey.key) > 0 {
+		return c.stripeKey.key, nil
+	}
+	resp := stripeConfigResponse{}
+	if err := c.get(ctx, c.url(stripeConfigPath), false, &resp); err != nil {
+		return "", errors.Wrap(err, "error getting stripe config")
+	}
+	if !strings.HasPrefix(resp.PublishableKey, "pk_") {
+		return "", errors.Errorf("invalid stripe publishable key %q", resp.PublishableKey)
+	}
+	c.stripeKey.key = resp.PublishableKey
+	return resp.PublishableKey, nil
+}
@@ -0,0 +1,125 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+const stripeUserJSON = `{"stripe_user": {"stripe_id": "cus_1", "cards": [
+	{"stripe_id": "card_1", "last4": "4242", "brand": "Visa", "exp_month": 1, "exp_year": 2030},
+	{"stripe_id": "card_2", "last4": "0005", "brand": "American Express", "exp_month": 2, "exp_year": 2031}
+]}}`
+
+func TestSavedCards(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /stripe", "POST /stripe":
+			fmt.Fprint(w, stripeUserJSON)
+		case "DELETE /stripe/card/card_1", "POST /stripe/card/card_1/default":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s\n", r.Method, r.URL.Path)
+		}
+	})
+	c.AuthToken = "abc"
+	ctx := context.Background()
+	cards, err := c.ListSavedCards(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 || cards[0] != (SavedCard{"card_1", "4242", "Visa", 1, 2030}) {
+		t.Errorf("unexpected cards: %+v\n", cards)
+	}
+	card, err := c.AddSavedCard(ctx, "tok_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.ID != "card_2" {
+		t.Errorf("expected %s; got %s\n", "card_2", card.ID)
+	}
+	if err := c.DeleteCard(ctx, "card_1"); err != nil {
+		t.Error(err)
+	}
+	if err := c.SetDefaultCard(ctx, "card_1"); err != nil {
+		t.Error(err)
+	}
+}
+
+// handlerTransport serves requests to any host (such as Stripe) with a handler.
+type handlerTransport struct {
+	http.Handler
+}
+
+func (h handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+func TestTokenizeCard(t *testing.T) {
+	c, err := New("test@example.com", WithMaxRetries(0), WithHTTPClient(&http.Client{Transport: handlerTransport{
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			if r.Form.Get("card[cvc]") == "999" {
+				w.WriteHeader(http.StatusPaymentRequired)
+				fmt.Fprint(w, `{"error": {"type": "card_error", "message": "Your card's security code is incorrect.", "param": "cvc"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "tok_1"}`)
+		}),
+	}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if tok, err := c.TokenizeCard(ctx, "4242 4242 4242 4242", "12", "99", "123"); err != nil || tok != "tok_1" {
+		t.Errorf("expected %s; got %s (%v)\n", "tok_1", tok, err)
+	}
+	for _, test := range []struct {
+		number, month, year, cvc, field string
+	}{
+		{"4242", "12", "2099", "123", "number"},
+		{"4242424242424242", "13", "2099", "123", "exp_month"},
+		{"4242424242424242", "12", "209", "123", "exp_year"},
+		{"4242424242424242", "12", "2001", "123", "exp_year"},
+		{"4242424242424242", "12", "2099", "12", "cvc"},
+		{"4242424242424242", "12", "2099", "999", "cvc"},
+	} {
+		cardErr := new(CardValidationError)
+		if _, err := c.TokenizeCard(ctx, test.number, test.month, test.year, test.cvc); !errors.As(err, &cardErr) || cardErr.Field != test.field {
+			t.Errorf("expected invalid %s; got %v\n", test.field, err)
+		}
+	}
+}
+
+func TestGetStripePublishableKey(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"publishable_key": "pk_test_123"}`)
+	})
+	for i := 0; i < 2; i++ {
+		key, err := c.GetStripePublishableKey(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != "pk_test_123" {
+			t.Errorf("expected pk_test_123; got %s\n", key)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request; got %d\n", requests)
+	}
+
+	for _, body := range []string{`{"publishable_key": ""}`, `{"publishable_key": "sk_live_123"}`} {
+		c := newTestClient(t, statusHandler(http.StatusOK, body))
+		if _, err := c.GetStripePublishableKey(context.Background()); err == nil {
+			t.Errorf("expected an error for %s\n", body)
+		}
+	}
+}
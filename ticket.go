@@ -0,0 +1,71 @@
+package fixr
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const defaultLocale = "en-US"
+
+// IsAvailable reports whether the ticket can currently be purchased
+// (i.e. it has not sold out or expired, and is already on sale).
+func (t Ticket) IsAvailable() bool {
+	return !t.SoldOut && !t.Expired && !t.Invalid
+}
+
+// IsOnSaleNow reports whether the current time is within the ticket's sale window.
+// A zero SaleStartTime or SaleEndTime leaves that end of the window open.
+func (t Ticket) IsOnSaleNow() bool {
+	now := time.Now()
+	return (t.SaleStartTime.IsZero() || !now.Before(t.SaleStartTime)) &&
+		(t.SaleEndTime.IsZero() || now.Before(t.SaleEndTime))
+}
+
+const saleWindowLayout = "2 Jan 2006 15:04 MST"
+
+// SaleWindowString describes the ticket's sale window, e.g. "16 Jun 2017 19:00 UTC - 17 Jun 2017
+// 03:00 UTC", "from ..." or "until ...". An empty string is returned if there is no sale window.
+func (t Ticket) SaleWindowString() string {
+	start, end := t.SaleStartTime.Format(saleWindowLayout), t.SaleEndTime.Format(saleWindowLayout)
+	switch {
+	case t.SaleStartTime.IsZero() && t.SaleEndTime.IsZero():
+		return ""
+	case t.SaleEndTime.IsZero():
+		return "from " + start
+	case t.SaleStartTime.IsZero():
+		return "until " + end
+	}
+	return start + " - " + end
+}
+
+// TotalCost returns the cost of a single ticket, including the booking fee.
+func (t Ticket) TotalCost() float64 {
+	return t.Price + t.BookingFee
+}
+
+// EffectivePrice returns the cost of a single ticket (including the booking fee)
+// when bought with promo, or TotalCost if promo is nil.
+func (t Ticket) EffectivePrice(promo *PromoCode) float64 {
+	if promo == nil {
+		return t.TotalCost()
+	}
+	return promo.Price + promo.BookingFee
+}
+
+// FormattedPrice returns TotalCost with the symbol of the ticket's currency, formatted
+// for the given BCP 47 locale (e.g. "en-GB"). The locale defaults to "en-US".
+func (t Ticket) FormattedPrice(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.MustParse(defaultLocale)
+	}
+	unit, err := currency.ParseISO(t.Currency)
+	if err != nil {
+		return fmt.Sprintf("%.2f %s", t.TotalCost(), t.Currency)
+	}
+	return message.NewPrinter(tag).Sprint(currency.Symbol(unit.Amount(t.TotalCost())))
+}
@@ -0,0 +1,71 @@
+package fixr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicketIsAvailable(t *testing.T) {
+	for _, test := range []struct {
+		ticket   Ticket
+		expected bool
+	}{
+		{Ticket{}, true},
+		{Ticket{SoldOut: true}, false},
+		{Ticket{Expired: true}, false},
+		{Ticket{Invalid: true}, false},
+	} {
+		if result := test.ticket.IsAvailable(); result != test.expected {
+			t.Errorf("expected %t; got %t (%+v)\n", test.expected, result, test.ticket)
+		}
+	}
+}
+
+func TestTicketEffectivePrice(t *testing.T) {
+	ticket := Ticket{Price: 10, BookingFee: 1.5}
+	if result, expected := ticket.EffectivePrice(nil), 11.5; result != expected {
+		t.Errorf("expected %.2f; got %.2f\n", expected, result)
+	}
+	if result, expected := ticket.EffectivePrice(&PromoCode{Price: 5, BookingFee: 0.5}), 5.5; result != expected {
+		t.Errorf("expected %.2f; got %.2f\n", expected, result)
+	}
+}
+
+func TestTicketFormattedPrice(t *testing.T) {
+	for _, test := range []struct {
+		currency, locale, expected string
+	}{
+		{"GBP", "en-GB", "£ 12.50"},
+		{"EUR", "de-DE", "€ 12,50"},
+		{"USD", "", "$ 12.50"},
+		{"USD", "invalid locale", "$ 12.50"},
+	} {
+		ticket := Ticket{Currency: test.currency, Price: 10, BookingFee: 2.5}
+		if result := ticket.FormattedPrice(test.locale); result != test.expected {
+			t.Errorf("expected %s; got %s\n", test.expected, result)
+		}
+	}
+}
+
+func TestTicketSaleWindow(t *testing.T) {
+	now := time.Now()
+	start, end := time.Date(2017, 5, 1, 9, 0, 0, 0, time.UTC), time.Date(2017, 6, 16, 18, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		ticket    Ticket
+		onSale    bool
+		formatted string
+	}{
+		{Ticket{}, true, ""},
+		{Ticket{SaleStartTime: start, SaleEndTime: end}, false, "1 May 2017 09:00 UTC - 16 Jun 2017 18:00 UTC"},
+		{Ticket{SaleStartTime: start}, true, "from 1 May 2017 09:00 UTC"},
+		{Ticket{SaleEndTime: end}, false, "until 16 Jun 2017 18:00 UTC"},
+		{Ticket{SaleStartTime: now.Add(time.Hour)}, false, ""},
+	} {
+		if result := test.ticket.IsOnSaleNow(); result != test.onSale {
+			t.Errorf("expected %t; got %t (%+v)\n", test.onSale, result, test.ticket)
+		}
+		if result := test.ticket.SaleWindowString(); len(test.formatted) > 0 && result != test.formatted {
+			t.Errorf("expected %s; got %s\n", test.formatted, result)
+		}
+	}
+}
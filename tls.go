@@ -0,0 +1,50 @@
+package fixr
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+)
+
+// withTLS is like withConnPool, but for options which modify the transport's tls.Config.
+func withTLS(fn func(*tls.Config) error) ClientOption {
+	return withConnPool(func(p *connPool) error {
+		if p.tls == nil {
+			p.tls = new(tls.Config)
+		}
+		return fn(p.tls)
+	})
+}
+
+// WithClientCertificate presents the PEM-encoded certificate and key when connecting, such as
+// to a TLS-inspecting proxy requiring mutual authentication. It cannot be combined with
+// WithHTTPClient.
+func WithClientCertificate(certPEM, keyPEM []byte) ClientOption {
+	return withTLS(func(config *tls.Config) error {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return errors.Wrap(err, "invalid client certificate")
+		}
+		config.Certificates = append(config.Certificates, cert)
+		return nil
+	})
+}
+
+// WithTLSRootCA trusts the PEM-encoded CA certificate(s), in addition to the system's.
+// It cannot be combined with WithHTTPClient.
+func WithTLSRootCA(caPEM []byte) ClientOption {
+	return withTLS(func(config *tls.Config) error {
+		if config.RootCAs == nil {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			config.RootCAs = pool
+		}
+		if !config.RootCAs.AppendCertsFromPEM(caPEM) {
+			return errors.New("invalid root CA: no certificates found")
+		}
+		return nil
+	})
+}
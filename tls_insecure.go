@@ -0,0 +1,16 @@
+//go:build !fixr_production
+// +build !fixr_production
+
+package fixr
+
+import "crypto/tls"
+
+// WithTLSInsecureSkipVerify disables verification of the server's certificate if skip is true,
+// for development only. It is unavailable (returning an error) when built with the
+// fixr_production tag, and cannot be combined with WithHTTPClient.
+func WithTLSInsecureSkipVerify(skip bool) ClientOption {
+	return withTLS(func(config *tls.Config) error {
+		config.InsecureSkipVerify = skip
+		return nil
+	})
+}
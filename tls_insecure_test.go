@@ -0,0 +1,25 @@
+//go:build !fixr_production
+// +build !fixr_production
+
+package fixr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithTLSInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	t.Cleanup(server.Close)
+	c, err := New("test@example.com", WithBaseURL(server.URL), WithMaxRetries(0), WithTLSInsecureSkipVerify(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Event(1); err != nil {
+		t.Error(err)
+	}
+}
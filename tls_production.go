@@ -0,0 +1,17 @@
+//go:build fixr_production
+// +build fixr_production
+
+package fixr
+
+import "github.com/pkg/errors"
+
+// WithTLSInsecureSkipVerify is unavailable when built with the fixr_production tag,
+// returning an error if skip is true.
+func WithTLSInsecureSkipVerify(skip bool) ClientOption {
+	return func(c *Client) error {
+		if skip {
+			return errors.New("insecure TLS is disabled in production builds")
+		}
+		return nil
+	}
+}
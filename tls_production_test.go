@@ -0,0 +1,15 @@
+//go:build fixr_production
+// +build fixr_production
+
+package fixr
+
+import "testing"
+
+func TestWithTLSInsecureSkipVerifyProduction(t *testing.T) {
+	if _, err := New("test@example.com", WithTLSInsecureSkipVerify(true)); err == nil {
+		t.Error("expected an error for insecure TLS in a production build")
+	}
+	if _, err := New("test@example.com", WithTLSInsecureSkipVerify(false)); err != nil {
+		t.Error(err)
+	}
+}
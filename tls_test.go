@@ -0,0 +1,85 @@
+package fixr
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestCertificate returns a self-signed PEM-encoded client certificate and key.
+func newTestCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fixr-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "fixr-test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	certPEM, keyPEM := newTestCertificate(t)
+
+	c, err := New("test@example.com", WithBaseURL(server.URL), WithMaxRetries(0), WithTLSRootCA(caPEM), WithClientCertificate(certPEM, keyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Event(1); err != nil {
+		t.Error(err)
+	}
+	c, err = New("test@example.com", WithBaseURL(server.URL), WithMaxRetries(0), WithClientCertificate(certPEM, keyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Event(1); err == nil {
+		t.Error("expected an error for an untrusted server certificate")
+	}
+}
+
+func TestTLSOptionsInvalidPEM(t *testing.T) {
+	certPEM, _ := newTestCertificate(t)
+	for name, opt := range map[string]ClientOption{
+		"client certificate": WithClientCertificate([]byte("invalid"), []byte("invalid")),
+		"mismatched key":     WithClientCertificate(certPEM, certPEM),
+		"root CA":            WithTLSRootCA([]byte("invalid")),
+	} {
+		if _, err := New("test@example.com", opt); err == nil {
+			t.Errorf("expected an error for an invalid %s\n", name)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//go:build fixr_otel
+// +build fixr_otel
+
+package fixr
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/gp508/fixr"
+
+// tracing creates an OpenTelemetry span for each request made by the client.
+type tracing struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider sets the TracerProvider used to create spans for each request
+// (the global provider is used by default). It is only available with the fixr_otel build tag.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Client) error {
+		c.tracing.provider = tp
+		return nil
+	}
+}
+
+func (t tracing) start(req *http.Request, auth bool) func(*http.Response, error) {
+	tp := t.provider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	ctx, span := tp.Tracer(tracerName).Start(req.Context(), "FIXR "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.url", req.URL.String()),
+			attribute.Bool("fixr.auth", auth),
+		),
+	)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return func(resp *http.Response, err error) {
+		defer span.End()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+//go:build fixr_otel
+// +build fixr_otel
+
+package fixr
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	var traceparent string
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		if r.URL.Path != "/event/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "tickets": []}`)
+	}, WithTracerProvider(tp))
+	if _, err := c.Event(1); err != nil {
+		t.Fatal(err)
+	}
+	if len(traceparent) == 0 {
+		t.Error("expected a traceparent header")
+	}
+	c.Event(2)
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected %d spans; got %d\n", 2, len(spans))
+	}
+	for i, expected := range []struct {
+		status int
+		code   codes.Code
+	}{{http.StatusOK, codes.Unset}, {http.StatusNotFound, codes.Error}} {
+		span := spans[i]
+		if span.Name() != "FIXR GET" || span.InstrumentationScope().Name != tracerName {
+			t.Errorf("expected %s from %s; got %s from %s\n", "FIXR GET", tracerName, span.Name(), span.InstrumentationScope().Name)
+		}
+		attrs := attribute.NewSet(span.Attributes()...)
+		if status, _ := attrs.Value("http.status_code"); status.AsInt64() != int64(expected.status) {
+			t.Errorf("expected status %d; got %v\n", expected.status, status.Emit())
+		}
+		if span.Status().Code != expected.code {
+			t.Errorf("expected %v; got %v\n", expected.code, span.Status().Code)
+		}
+	}
+}
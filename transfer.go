@@ -0,0 +1,42 @@
+package fixr
+
+import (
+	"context"
+	"net/mail"
+
+	"github.com/pkg/errors"
+)
+
+// TransferTicket transfers the booking with the given ID to another user, who must
+// accept it (see AcceptTransfer) using the token emailed to recipientEmail.
+// A *TransferNotAllowedError will be returned if the organiser has disabled transfers.
+func (c *Client) TransferTicket(ctx context.Context, bookingID int, recipientEmail string) error {
+	if _, err := mail.ParseAddress(recipientEmail); err != nil {
+		return &ValidationError{Field: "email", Message: err.Error()}
+	}
+	data, err := jsonifyPayload(payload{"email": recipientEmail})
+	if err != nil {
+		return err
+	}
+	if err := c.post(ctx, c.url(transferPath, bookingID), data, true, new(apiError)); err != nil {
+		if errorCode(err) == codeTransferDisabled {
+			return &TransferNotAllowedError{*asAPIError(err)}
+		}
+		return errors.Wrap(err, "error transferring ticket")
+	}
+	return nil
+}
+
+// AcceptTransfer accepts a ticket transferred to the user, returning the new booking.
+// The booking's PDF URL differs from the one held by the original owner, which is no longer valid.
+func (c *Client) AcceptTransfer(ctx context.Context, transferToken string) (*Booking, error) {
+	data, err := jsonifyPayload(payload{"token": transferToken})
+	if err != nil {
+		return nil, err
+	}
+	booking := Booking{}
+	if err := c.post(ctx, c.url(acceptPath), data, true, &booking); err != nil {
+		return nil, errors.Wrap(err, "error accepting transfer")
+	}
+	return &booking, nil
+}
@@ -0,0 +1,27 @@
+package fixr
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestTransferTicket(t *testing.T) {
+	c := newTestClient(t, statusHandler(http.StatusBadRequest, `{"message": "disabled", "code": "transfer_disabled"}`))
+	validationErr := new(ValidationError)
+	if err := c.TransferTicket(context.Background(), 1, "not-an-email"); !errors.As(err, &validationErr) {
+		t.Errorf("expected *ValidationError; got %v\n", err)
+	}
+	c.AuthToken = "abc"
+	transferErr := new(TransferNotAllowedError)
+	if err := c.TransferTicket(context.Background(), 1, "friend@example.com"); !errors.As(err, &transferErr) {
+		t.Errorf("expected *TransferNotAllowedError; got %v\n", err)
+	}
+	c = newTestClient(t, statusHandler(http.StatusForbidden, `{"message": "disabled", "code": "transfer_disabled"}`))
+	c.AuthToken = "abc"
+	if err := c.TransferTicket(context.Background(), 1, "friend@example.com"); !errors.As(err, &transferErr) {
+		t.Errorf("expected *TransferNotAllowedError; got %v\n", err)
+	}
+}
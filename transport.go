@@ -0,0 +1,121 @@
+package fixr
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 500 * time.Millisecond
+	// maxRetryAfter is the longest Retry-After waited for before retrying. Responses asking
+	// for a longer wait are returned instead (e.g. as a *RateLimitError with its RetryAfter).
+	maxRetryAfter = 30 * time.Second
+)
+
+func orDefaultTransport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		return http.DefaultTransport
+	}
+	return rt
+}
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// withIdempotencyKey returns a copy of ctx which marks requests made with it as safe to
+// retry, sending key in the Idempotency-Key header (see Client.do).
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// retryableTransport retries requests which fail with a network error or a transient
+// HTTP status, backing off exponentially with full jitter between attempts (or for the
+// response's Retry-After, if longer, up to 30 seconds). Only idempotent requests are retried: GET, HEAD and
+// OPTIONS requests, and those carrying an Idempotency-Key header (such as from Book).
+type retryableTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableRequest reports whether req can be repeated without side effects.
+func retryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body will have been consumed and cannot be replayed.
+		return false
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return len(req.Header.Get(idempotencyKeyHeader)) > 0
+}
+
+func (t *retryableTransport) backoff(attempt int) time.Duration {
+	ceiling := t.baseDelay << uint(attempt)
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+func (t *retryableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := orDefaultTransport(t.base)
+	if t.maxRetries == 0 || !retryableRequest(req) {
+		return base.RoundTrip(req)
+	}
+	for attempt := 0; ; attempt++ {
+		// A RoundTripper must not modify req, so each retry is made with a clone.
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+		resp, err := base.RoundTrip(attemptReq)
+		if attempt == t.maxRetries || (err == nil && !retryableStatus(resp.StatusCode)) {
+			return resp, err
+		}
+		delay := t.backoff(attempt)
+		if err == nil {
+			wait := time.Until(parseRetryAfter(resp.Header.Get("Retry-After")))
+			if wait > maxRetryAfter {
+				return resp, nil
+			}
+			if wait > delay {
+				delay = wait
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package fixr
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type countingTransport struct {
+	calls  int
+	status int
+	err    error
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	if t.err != nil {
+		return nil, t.err
+	}
+	return &http.Response{StatusCode: t.status, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+}
+
+func TestRetryableTransport(t *testing.T) {
+	for _, test := range []struct {
+		status   int
+		err      error
+		expected int
+	}{
+		{http.StatusOK, nil, 1},
+		{http.StatusBadRequest, nil, 1},
+		{http.StatusUnauthorized, nil, 1},
+		{http.StatusTooManyRequests, nil, 4},
+		{http.StatusServiceUnavailable, nil, 4},
+		{http.StatusGatewayTimeout, nil, 4},
+		{0, errors.New("connection refused"), 4},
+	} {
+		stub := &countingTransport{status: test.status, err: test.err}
+		transport := &retryableTransport{base: stub, maxRetries: 3, baseDelay: 0}
+		req, _ := http.NewRequest("GET", "https://example.com", nil)
+		if resp, err := transport.RoundTrip(req); err == nil {
+			resp.Body.Close()
+		}
+		if stub.calls != test.expected {
+			t.Errorf("expected %d calls; got %d (status: %d; err: %v)\n", test.expected, stub.calls, test.status, test.err)
+		}
+	}
+}
+
+type bodyRecordingTransport struct {
+	bodies []string
+	reqs   []*http.Request
+}
+
+func (t *bodyRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	t.bodies = append(t.bodies, string(body))
+	t.reqs = append(t.reqs, req)
+	return &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+}
+
+func TestRetryableTransportIdempotency(t *testing.T) {
+	for _, test := range []struct {
+		method   string
+		key      string
+		expected int
+	}{
+		{"POST", "", 1},
+		{"PATCH", "", 1},
+		{"DELETE", "", 1},
+		{"POST", "key", 3},
+	} {
+		stub := &bodyRecordingTransport{}
+		transport := &retryableTransport{base: stub, maxRetries: 2, baseDelay: 0}
+		req, _ := http.NewRequest(test.method, "https://example.com", strings.NewReader(`{"a": 1}`))
+		if len(test.key) > 0 {
+			req.Header.Set(idempotencyKeyHeader, test.key)
+		}
+		if resp, err := transport.RoundTrip(req); err == nil {
+			resp.Body.Close()
+		}
+		if len(stub.bodies) != test.expected {
+			t.Errorf("expected %d calls; got %d (%s; key: %q)\n", test.expected, len(stub.bodies), test.method, test.key)
+		}
+		for i, body := range stub.bodies {
+			if body != `{"a": 1}` {
+				t.Errorf("expected the body to be replayed; got %q\n", body)
+			}
+			if i > 0 && stub.reqs[i] == req {
+				t.Error("expected retries to be made with a clone of the request")
+			}
+		}
+	}
+}
+
+func TestRetryableTransportRetryAfter(t *testing.T) {
+	calls := 0
+	stub := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		header := http.Header{}
+		if calls == 1 {
+			header.Set("Retry-After", "1")
+			return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})
+	transport := &retryableTransport{base: stub, maxRetries: 1, baseDelay: 0}
+	req, _ := http.NewRequest("GET", "https://example.com", nil)
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); calls != 2 || elapsed < 900*time.Millisecond {
+		t.Errorf("expected a retry after at least 1s; got %d calls after %s\n", calls, elapsed)
+	}
+}
+
+func TestRetryableTransportLongRetryAfter(t *testing.T) {
+	calls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, WithRetry(3, time.Millisecond))
+	start := time.Now()
+	_, err := c.Event(1)
+	rateErr := new(RateLimitError)
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected *RateLimitError; got %v\n", err)
+	}
+	if elapsed := time.Since(start); calls != 1 || elapsed > time.Second {
+		t.Errorf("expected no retry; got %d calls after %s\n", calls, elapsed)
+	}
+	if until := time.Until(rateErr.RetryAfter); until < 23*time.Hour {
+		t.Errorf("expected RetryAfter in a day; got %s\n", until)
+	}
+}
+
+func TestRetriesSendPOSTOnce(t *testing.T) {
+	var posts, gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			atomic.AddInt32(&posts, 1)
+		} else {
+			atomic.AddInt32(&gets, 1)
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+	c, err := New("test@example.com", WithBaseURL(server.URL), WithRetry(2, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.AuthToken = "abc"
+	if _, err := c.Book(&Ticket{ID: 1, Max: 1}, 1, nil, WithoutTicketRefresh()); err == nil {
+		t.Error("expected an error")
+	}
+	if posts != 1 {
+		t.Errorf("expected %d POST for a free ticket; got %d\n", 1, posts)
+	}
+	// Paid bookings carry a purchase key, and so are retried.
+	if _, err := c.Book(&Ticket{ID: 1, Max: 1, Price: 10}, 1, nil, WithoutTicketRefresh()); err == nil {
+		t.Error("expected an error")
+	}
+	if posts != 4 {
+		t.Errorf("expected %d POSTs; got %d\n", 4, posts)
+	}
+	if _, err := c.Event(1); err == nil {
+		t.Error("expected an error")
+	}
+	if gets != 3 {
+		t.Errorf("expected %d GETs; got %d\n", 3, gets)
+	}
+}
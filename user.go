@@ -0,0 +1,269 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func (c *Client) requireAuth() error {
+	if len(c.AuthToken) == 0 {
+		return errNotAuthenticated()
+	}
+	return nil
+}
+
+// ValidateToken checks that the client's auth token is still accepted by FIXR.
+// An *AuthError will be returned if it is not.
+func (c *Client) ValidateToken(ctx context.Context) error {
+	if err := c.requireAuth(); err != nil {
+		return err
+	}
+	if err := c.get(ctx, c.url(mePath), true, new(Client)); err != nil {
+		return errors.Wrap(err, "error validating token")
+	}
+	return nil
+}
+
+// Me refreshes the user's details (name, magic login URL and Stripe details)
+// from FIXR, without needing to Logon again.
+func (c *Client) Me(ctx context.Context) error {
+	if err := c.requireAuth(); err != nil {
+		return err
+	}
+	if err := c.get(ctx, c.url(mePath), true, c); err != nil {
+		return errors.Wrap(err, "error getting user details")
+	}
+	return nil
+}
+
+// Logout revokes the client's auth token and clears the user's details and cached
+// recommendations, after which authenticated methods return an *AuthError without making
+// a request until Logon is called again.
+func (c *Client) Logout(ctx context.Context) error {
+	if err := c.post(ctx, c.url(logoutPath), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error logging out")
+	}
+	c.resetSession()
+	return nil
+}
+
+// resetSession leaves the client unauthenticated, clearing everything it holds about the user
+// other than Email.
+func (c *Client) resetSession() {
+	c.AuthToken, c.MagicURL, c.StripeUser = "", "", nil
+	c.FirstName, c.LastName, c.Phone, c.AvatarURL = "", "", "", ""
+	c.recommended.clear()
+}
+
+// LinkAppleSignIn authenticates the client with the identity token given to an app by Sign in
+// with Apple, populating the user's details as Logon does. A *ValidationError will be returned
+// (without making a request) if the token is empty, and an *OAuthError if it is invalid or has
+// expired.
+func (c *Client) LinkAppleSignIn(ctx context.Context, identityToken string) error {
+	if len(identityToken) == 0 {
+		return &ValidationError{Field: "identity_token", Message: "cannot be empty"}
+	}
+	data, err := jsonifyPayload(payload{
+		"identity_token": base64.StdEncoding.EncodeToString([]byte(identityToken)),
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.post(ctx, c.url(appleLoginPath), data, false, c); err != nil {
+		// The token is rejected with either a 400 or 401 (*AuthError), so both are matched via base.
+		var e interface{ base() *APIError }
+		if errors.As(err, &e) && (e.base().StatusCode == http.StatusBadRequest || e.base().StatusCode == http.StatusUnauthorized) {
+			return &OAuthError{APIError: *e.base(), Provider: "apple"}
+		}
+		return errors.Wrap(err, "error signing in with apple")
+	}
+	return nil
+}
+
+// DeleteUserAccount permanently deletes the user's FIXR account and personal data, confirmed
+// with their password, after which the client is no longer authenticated and holds none of
+// the user's details (as after Logout, but also clearing Email).
+// A *PasswordMismatchError will be returned if the password is incorrect.
+func (c *Client) DeleteUserAccount(ctx context.Context, password string) error {
+	if err := c.requireAuth(); err != nil {
+		return err
+	}
+	if len(password) == 0 {
+		return &ValidationError{Field: "password", Message: "cannot be empty"}
+	}
+	data, err := jsonifyPayload(payload{"password": password})
+	if err != nil {
+		return err
+	}
+	if err := c.post(ctx, c.url(deleteUserPath), data, true, new(apiError)); err != nil {
+		if errorCode(err) == codePasswordMismatch {
+			return &PasswordMismatchError{*asAPIError(err)}
+		}
+		return errors.Wrap(err, "error deleting account")
+	}
+	c.resetSession()
+	c.Email = ""
+	return nil
+}
+
+// RequestDataExport asks FIXR to email the user a copy of their personal data.
+func (c *Client) RequestDataExport(ctx context.Context) error {
+	if err := c.requireAuth(); err != nil {
+		return err
+	}
+	if err := c.post(ctx, c.url(dataExportPath), new(bytes.Buffer), true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error requesting data export")
+	}
+	return nil
+}
+
+const minPasswordLength = 8
+
+// RegisterUser creates a FIXR account with the given details and authenticates
+// the client with it, so Logon does not need to be called.
+// A *ValidationError will be returned (without making a request) if the email address is empty
+// or the password is too short, and a *RegistrationError if the email address is already in use.
+func (c *Client) RegisterUser(ctx context.Context, email, password, firstName, lastName string) error {
+	if len(email) == 0 {
+		return &ValidationError{Field: "email", Message: "cannot be empty"}
+	}
+	if len(password) < minPasswordLength {
+		return &ValidationError{Field: "password", Message: fmt.Sprintf("must be at least %d characters", minPasswordLength)}
+	}
+	pl := payload{
+		"email":      email,
+		"password":   password,
+		"first_name": firstName,
+		"last_name":  lastName,
+	}
+	data, err := jsonifyPayload(pl)
+	if err != nil {
+		return err
+	}
+	if err := c.post(ctx, c.url(signupPath), data, false, c); err != nil {
+		if errorCode(err) == codeEmailInUse {
+			return &RegistrationError{*asAPIError(err)}
+		}
+		return errors.Wrap(err, "error registering user")
+	}
+	c.Email = email
+	return nil
+}
+
+// UserProfile contains the editable details of the user's FIXR account.
+type UserProfile struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Phone     string `json:"phone,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// UpdateUserProfile updates the user's FIXR account with p, and then the client's corresponding
+// fields. An empty Phone or AvatarURL is left unchanged. A *ValidationError will be returned
+// (without making a request) if FirstName or LastName is empty.
+func (c *Client) UpdateUserProfile(ctx context.Context, p UserProfile) error {
+	var invalid []string
+	if len(strings.TrimSpace(p.FirstName)) == 0 {
+		invalid = append(invalid, "first_name")
+	}
+	if len(strings.TrimSpace(p.LastName)) == 0 {
+		invalid = append(invalid, "last_name")
+	}
+	if len(invalid) > 0 {
+		return &ValidationError{Field: strings.Join(invalid, ", "), Message: "cannot be empty"}
+	}
+	data := new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(p); err != nil {
+		return errors.Wrap(err, "error encoding profile")
+	}
+	if err := c.patch(ctx, c.url(mePath), data, true, new(apiError)); err != nil {
+		return errors.Wrap(err, "error updating profile")
+	}
+	c.FirstName, c.LastName = p.FirstName, p.LastName
+	if len(p.Phone) > 0 {
+		c.Phone = p.Phone
+	}
+	if len(p.AvatarURL) > 0 {
+		c.AvatarURL = p.AvatarURL
+	}
+	return nil
+}
+
+type avatarResponse struct {
+	apiError
+	AvatarURL string `json:"avatar_url"`
+}
+
+// UploadAvatar uploads the image read from r (of the given MIME type, e.g. "image/png") as
+// the user's avatar, returning its URL. The client's AvatarURL is also updated.
+func (c *Client) UploadAvatar(ctx context.Context, r io.Reader, contentType string) (string, error) {
+	data := new(bytes.Buffer)
+	form := multipart.NewWriter(data)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="avatar"; filename="avatar"`)
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return "", errors.Wrap(err, "error creating avatar form")
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return "", errors.Wrap(err, "error reading avatar")
+	}
+	if err := form.Close(); err != nil {
+		return "", errors.Wrap(err, "error creating avatar form")
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url(avatarPath), data)
+	if err != nil {
+		return "", errors.New("error creating POST request")
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp := avatarResponse{}
+	if err := c.req(req, true, &resp); err != nil {
+		return "", errors.Wrap(err, "error uploading avatar")
+	}
+	c.AvatarURL = resp.AvatarURL
+	return resp.AvatarURL, nil
+}
+
+// UserStats contains statistics about the user's bookings.
+type UserStats struct {
+	apiError
+	TotalEventsAttended int            `json:"total_events_attended"`
+	TotalMoneySpent     float64        `json:"total_money_spent"`
+	PreferredCurrency   string         `json:"preferred_currency"`
+	FavouriteGenre      string         `json:"favourite_genre"`
+	BookingsThisYear    int            `json:"bookings_this_year"`
+	MonthlySpend        []MonthlySpend `json:"monthly_spend"`
+}
+
+// MonthlySpend is the amount spent by the user in a single month.
+type MonthlySpend struct {
+	Month  time.Month `json:"month"`
+	Year   int        `json:"year"`
+	Amount float64    `json:"amount"`
+}
+
+// GetUserStats returns statistics about the user's bookings. Empty statistics (and no error)
+// are returned for users without any bookings, for whom the API responds without a body or
+// with the no_booking_history error code.
+func (c *Client) GetUserStats(ctx context.Context) (*UserStats, error) {
+	stats := UserStats{}
+	if err := c.get(ctx, c.url(statsPath), true, &stats); err != nil {
+		if errorCode(err) == codeNoBookingHistory {
+			return &UserStats{}, nil
+		}
+		return nil, errors.Wrap(err, "error getting user stats")
+	}
+	return &stats, nil
+}
@@ -0,0 +1,299 @@
+package fixr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestValidateToken(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"first_name": "Test"}`)
+	})
+	c.AuthToken = "valid"
+	if err := c.ValidateToken(context.Background()); err != nil {
+		t.Errorf("expected valid token; got %v\n", err)
+	}
+	c.AuthToken = "invalid"
+	authErr := new(AuthError)
+	if err := c.ValidateToken(context.Background()); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+}
+
+func TestNewClientFromToken(t *testing.T) {
+	c, err := NewClientFromToken("test@example.com", "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.AuthToken != "abc" {
+		t.Errorf("expected %s; got %s\n", "abc", c.AuthToken)
+	}
+}
+
+func TestMe(t *testing.T) {
+	name := "Before"
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"first_name": "%s", "last_name": "User", "stripe_user": {"stripe_id": "cus_1", "cards": [{"last4": "4242"}]}}`, name)
+	})
+	c.AuthToken = "abc"
+	if err := c.Me(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	name = "After"
+	if err := c.Me(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if c.FirstName != "After" || c.LastName != "User" {
+		t.Errorf("expected %s %s; got %s %s\n", "After", "User", c.FirstName, c.LastName)
+	}
+	if c.StripeUser == nil || len(c.StripeUser.Cards) != 1 {
+		t.Errorf("expected stripe user with one card; got %+v\n", c.StripeUser)
+	}
+	if c.AuthToken != "abc" {
+		t.Errorf("expected %s; got %s\n", "abc", c.AuthToken)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/user/logout" {
+			t.Errorf("expected %s; got %s\n", "/user/logout", r.URL.Path)
+		}
+	})
+	c.AuthToken, c.FirstName = "abc", "Test"
+	if err := c.Logout(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.AuthToken) > 0 || len(c.FirstName) > 0 {
+		t.Errorf("expected auth token and user details to be cleared; got %s (%s)\n", c.AuthToken, c.FirstName)
+	}
+	authErr := new(AuthError)
+	if _, err := c.Book(&Ticket{ID: 1, Max: 1}, 1, nil, WithoutTicketRefresh()); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+	if err := c.Logout(context.Background()); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected %d; got %d\n", 1, requests)
+	}
+}
+
+func TestRegisterUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"first_name": "New", "auth_token": "abc"}`)
+	})
+	if err := c.RegisterUser(context.Background(), "new@example.com", "password1", "New", "User"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Email != "new@example.com" || c.AuthToken != "abc" || c.FirstName != "New" {
+		t.Errorf("unexpected client state: %+v\n", c)
+	}
+}
+
+func TestRegisterUserEmailInUse(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusForbidden} {
+		c := newTestClient(t, statusHandler(status, `{"message": "in use", "code": "email_in_use"}`))
+		regErr := new(RegistrationError)
+		if err := c.RegisterUser(context.Background(), "new@example.com", "password1", "", ""); !errors.As(err, &regErr) {
+			t.Errorf("expected *RegistrationError; got %v (status: %d)\n", err, status)
+		}
+	}
+	c := newTestClient(t, statusHandler(http.StatusBadRequest, `{"message": "in use", "code": "email_in_use"}`))
+	for field, details := range map[string][2]string{
+		"email":    {"", "password1"},
+		"password": {"new@example.com", "short"},
+	} {
+		validationErr := new(ValidationError)
+		if err := c.RegisterUser(context.Background(), details[0], details[1], "", ""); !errors.As(err, &validationErr) || validationErr.Field != field {
+			t.Errorf("expected *ValidationError for %s; got %v\n", field, err)
+		}
+	}
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/user/me" {
+			t.Errorf("expected %s; got %s %s\n", "PATCH /user/me", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if expected := `{"first_name":"Ada","last_name":"Lovelace","phone":"+447700900000"}`; strings.TrimSpace(string(body)) != expected {
+			t.Errorf("expected %s; got %s\n", expected, body)
+		}
+	})
+	c.AuthToken = "abc"
+	c.AvatarURL = "https://example.com/old.png"
+	if err := c.UpdateUserProfile(context.Background(), UserProfile{FirstName: "Ada", LastName: "Lovelace", Phone: "+447700900000"}); err != nil {
+		t.Fatal(err)
+	}
+	if c.FirstName != "Ada" || c.LastName != "Lovelace" || c.Phone != "+447700900000" || c.AvatarURL != "https://example.com/old.png" {
+		t.Errorf("expected client to be updated; got %s %s %s %s\n", c.FirstName, c.LastName, c.Phone, c.AvatarURL)
+	}
+
+	validationErr := new(ValidationError)
+	if err := c.UpdateUserProfile(context.Background(), UserProfile{LastName: " "}); !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError; got %v\n", err)
+	}
+	if validationErr.Field != "first_name, last_name" {
+		t.Errorf("expected %s; got %s\n", "first_name, last_name", validationErr.Field)
+	}
+}
+
+func TestUploadAvatar(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("avatar")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		if string(data) != "png" || header.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("expected %s (%s); got %s (%s)\n", "png", "image/png", data, header.Header.Get("Content-Type"))
+		}
+		fmt.Fprint(w, `{"avatar_url": "https://example.com/avatar.png"}`)
+	})
+	c.AuthToken = "abc"
+	avatarURL, err := c.UploadAvatar(context.Background(), strings.NewReader("png"), "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if avatarURL != "https://example.com/avatar.png" || c.AvatarURL != avatarURL {
+		t.Errorf("expected %s; got %s (client: %s)\n", "https://example.com/avatar.png", avatarURL, c.AvatarURL)
+	}
+}
+
+func TestLinkAppleSignIn(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var pl struct {
+			IdentityToken string `json:"identity_token"`
+		}
+		json.NewDecoder(r.Body).Decode(&pl)
+		if pl.IdentityToken != "ZXhwaXJlZA==" {
+			fmt.Fprint(w, `{"first_name": "Ada", "last_name": "Lovelace", "auth_token": "abc"}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message": "token expired", "code": "token_expired"}`)
+	})
+	if err := c.LinkAppleSignIn(context.Background(), "valid"); err != nil {
+		t.Fatal(err)
+	}
+	if c.AuthToken != "abc" || c.FirstName != "Ada" || c.LastName != "Lovelace" {
+		t.Errorf("unexpected client state: %+v\n", c)
+	}
+	oauthErr := new(OAuthError)
+	if err := c.LinkAppleSignIn(context.Background(), "expired"); !errors.As(err, &oauthErr) || oauthErr.Provider != "apple" {
+		t.Errorf("expected *OAuthError; got %v\n", err)
+	}
+	validationErr := new(ValidationError)
+	if err := c.LinkAppleSignIn(context.Background(), ""); !errors.As(err, &validationErr) || validationErr.Field != "identity_token" {
+		t.Errorf("expected *ValidationError; got %v\n", err)
+	}
+}
+
+func TestGetUserStats(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Token new":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "no bookings", "code": "no_booking_history"}`)
+			return
+		case "Token empty":
+			return
+		case "Token missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"total_events_attended": 4, "total_money_spent": 120.5, "preferred_currency": "GBP",
+			"bookings_this_year": 2, "monthly_spend": [{"month": 6, "year": 2017, "amount": 45.0}]}`)
+	})
+	c.AuthToken = "abc"
+	stats, err := c.GetUserStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalEventsAttended != 4 || len(stats.MonthlySpend) != 1 || stats.MonthlySpend[0].Month != time.June {
+		t.Errorf("unexpected stats: %+v\n", stats)
+	}
+	for _, token := range []string{"new", "empty"} {
+		c.AuthToken = token
+		if stats, err := c.GetUserStats(context.Background()); err != nil || stats.TotalEventsAttended != 0 {
+			t.Errorf("expected empty stats; got %+v (%v)\n", stats, err)
+		}
+	}
+	c.AuthToken = "missing"
+	notFoundErr := new(NotFoundError)
+	if _, err := c.GetUserStats(context.Background()); !errors.As(err, &notFoundErr) {
+		t.Errorf("expected *NotFoundError; got %v\n", err)
+	}
+}
+
+func TestDeleteUserAccount(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/delete" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body := struct {
+			Password string `json:"password"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Password != "correct-password" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "incorrect password", "code": "password_mismatch"}`)
+		}
+	})
+	authErr := new(AuthError)
+	if err := c.DeleteUserAccount(context.Background(), "correct-password"); !errors.As(err, &authErr) {
+		t.Errorf("expected *AuthError; got %v\n", err)
+	}
+	c.AuthToken = "abc"
+	mismatchErr := new(PasswordMismatchError)
+	if err := c.DeleteUserAccount(context.Background(), "wrong-password"); !errors.As(err, &mismatchErr) {
+		t.Errorf("expected *PasswordMismatchError; got %v\n", err)
+	}
+	if c.AuthToken != "abc" {
+		t.Error("expected the auth token to be kept")
+	}
+	c.FirstName, c.MagicURL, c.StripeUser = "Test", "https://fixr.co/magic", &stripeUser{}
+	c.recommended.result = &RecommendationResult{}
+	if err := c.DeleteUserAccount(context.Background(), "correct-password"); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.AuthToken) > 0 || len(c.Email) > 0 || len(c.FirstName) > 0 || len(c.MagicURL) > 0 || c.StripeUser != nil {
+		t.Errorf("expected the user's details to be cleared; got %+v\n", c)
+	}
+	if c.recommended.result != nil {
+		t.Error("expected cached recommendations to be cleared")
+	}
+}
+
+func TestRequestDataExport(t *testing.T) {
+	requested := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = r.Method == "POST" && r.URL.Path == "/user/data-export"
+	})
+	authErr := new(AuthError)
+	if err := c.RequestDataExport(context.Background()); !errors.As(err, &authErr) || requested {
+		t.Errorf("expected *AuthError without a request; got %v\n", err)
+	}
+	c.AuthToken = "abc"
+	if err := c.RequestDataExport(context.Background()); err != nil || !requested {
+		t.Errorf("expected a data export request; got %v\n", err)
+	}
+}
@@ -3,34 +3,145 @@ package fixr
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
-	"math/rand"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 )
 
-var (
-	seededRand *rand.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
-)
+// randReader is the source of purchase keys and request IDs; it is replaced in tests.
+var randReader io.Reader = rand.Reader
+
+// purchaseKeyBytes is the number of random bytes (128 bits) in a purchase key.
+const purchaseKeyBytes = 16
 
-func uuid() string {
-	segment := math.Floor(65536 * (1 + seededRand.Float64()))
-	return strconv.FormatInt(int64(segment), 16)[1:]
+// genKey returns a random, URL-safe purchase key. The API uses the key to deduplicate
+// payments, so reusing a key is safe when retrying a failed payment for the same booking.
+func genKey() (string, error) {
+	b := make([]byte, purchaseKeyBytes)
+	if _, err := io.ReadFull(randReader, b); err != nil {
+		return "", errors.Wrap(err, "error generating purchase key")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
-func genKey() string {
-	segments := make([]interface{}, 8)
-	for i := range segments {
-		segments[i] = uuid()
+// withTimeout returns a context derived from ctx which is cancelled after d,
+// or ctx itself if d is not positive.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
 	}
-	return fmt.Sprintf("%s%s-%s-%s-%s-%s%s%s", segments...)
+	return context.WithTimeout(ctx, d)
+}
+
+// withoutCancel returns a copy of ctx which keeps its values, but is never cancelled and has
+// no deadline. It is equivalent to context.WithoutCancel, which requires Go 1.21.
+func withoutCancel(ctx context.Context) context.Context {
+	return detachedContext{ctx}
+}
+
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// flightGroup deduplicates concurrent calls with the same key, like singleflight.Group. Unlike
+// singleflight, the shared call is not cancelled with the context of the caller which started
+// it, so that it giving up does not fail the others; each caller instead stops waiting once its
+// own context is done, and the call is cancelled once every caller has stopped waiting.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	val     interface{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(withoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			call.val, call.err = fn(callCtx)
+			cancel()
+			g.forget(key, call)
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		abandoned := call.waiters == 0
+		if abandoned && g.calls[key] == call {
+			// Later callers must not join the cancelled call.
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		if abandoned {
+			call.cancel()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes call from the group, if it is still the call for key.
+func (g *flightGroup) forget(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// parallel calls fn for each index in [0, n), running at most limit calls at once.
+func parallel(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
 }
 
 func buildURLValues(values payload) (url.Values, error) {
@@ -68,7 +179,16 @@ func unmarshalOutput(section string) (*scrapeOutput, error) {
 
 // UpdateVersion updates the FIXR API version used in the HTTP requests.
 func UpdateVersion() error {
-	req, err := http.Get(homeURL)
+	return UpdateVersionWithContext(context.Background())
+}
+
+// UpdateVersionWithContext is like UpdateVersion but uses ctx for the underlying HTTP request.
+func UpdateVersionWithContext(ctx context.Context) error {
+	r, err := http.NewRequestWithContext(ctx, "GET", homeURL, nil)
+	if err != nil {
+		return errors.New("error creating GET request")
+	}
+	req, err := http.DefaultClient.Do(r)
 	if err != nil {
 		return errors.Wrap(err, "failed to update fixr.FixrVersion")
 	}
@@ -81,7 +201,7 @@ func UpdateVersion() error {
 		}
 		out, err := unmarshalOutput(section)
 		if len(out.Version) > 0 {
-			FixrVersion = out.Version
+			SetFixrVersion(out.Version, "")
 		}
 		return err
 	}
@@ -90,3 +210,12 @@ func UpdateVersion() error {
 	}
 	return errors.New("search for APP_VERSION failed")
 }
+
+// moneyEpsilon is the largest difference between two amounts of money considered equal,
+// allowing for floating-point error.
+const moneyEpsilon = 0.001
+
+// moneyEqual reports whether the amounts of money a and b are equal.
+func moneyEqual(a, b float64) bool {
+	return math.Abs(a-b) < moneyEpsilon
+}
@@ -1,27 +1,28 @@
 package fixr
 
 import (
+	"bytes"
 	"fmt"
-	"math/rand"
+	"io"
 	"testing"
 )
 
-func init() {
-	seededRand = rand.New(rand.NewSource(0))
-}
-
-func TestUUID(t *testing.T) {
-	result, expected := uuid(), "f1f8"
-	if result != expected {
-		t.Errorf("expected %s; got %s\n", expected, result)
-	}
-}
-
 func TestGenKey(t *testing.T) {
-	result, expected := genKey(), "3eb6a7ec-0de9-5e1a-4a1b-3143a7c3e5ac"
-	if result != expected {
+	defer func(r io.Reader) { randReader = r }(randReader)
+	randReader = bytes.NewReader([]byte{
+		0xfb, 0xff, 0xbf, 0x00, 0x01, 0x02, 0x03, 0x04,
+		0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c,
+	})
+	result, err := genKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "-_-_AAECAwQFBgcICQoLDA"; result != expected {
 		t.Errorf("expected %s; got %s\n", expected, result)
 	}
+	if _, err := genKey(); err == nil {
+		t.Error("expected exhausted reader to fail")
+	}
 }
 
 func TestUnmarshalOutputNormal(t *testing.T) {
@@ -0,0 +1,38 @@
+package fixr
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Venue contains the location details of an event.
+type Venue struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Address string  `json:"address"`
+	City    string  `json:"city"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lng     float64 `json:"lng"`
+}
+
+type venueResponse struct {
+	apiError
+	Venue
+}
+
+// GetVenue returns the venue information for a given venue ID.
+// An error will be returned if one is encountered.
+func (c *Client) GetVenue(id int) (*Venue, error) {
+	return c.GetVenueWithContext(context.Background(), id)
+}
+
+// GetVenueWithContext is like GetVenue but uses ctx for the underlying HTTP request.
+func (c *Client) GetVenueWithContext(ctx context.Context, id int) (*Venue, error) {
+	resp := venueResponse{}
+	if err := c.get(ctx, c.url(venuePath, id), false, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting venue")
+	}
+	return &resp.Venue, nil
+}
@@ -0,0 +1,36 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestGetVenue(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/venue/3" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"detail": "Not found."}`)
+			return
+		}
+		if auth := r.Header.Get("Authorization"); len(auth) > 0 {
+			t.Errorf("expected no Authorization header; got %s\n", auth)
+		}
+		fmt.Fprint(w, `{"id": 3, "name": "Ministry of Sound", "address": "103 Gaunt St", "city": "London", "country": "GB", "lat": 51.4979, "lng": -0.0998}`)
+	})
+	v, err := c.GetVenue(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Venue{ID: 3, Name: "Ministry of Sound", Address: "103 Gaunt St", City: "London", Country: "GB", Lat: 51.4979, Lng: -0.0998}
+	if *v != expected {
+		t.Errorf("expected %+v; got %+v\n", expected, *v)
+	}
+	notFoundErr := new(NotFoundError)
+	if _, err := c.GetVenueWithContext(context.Background(), 4); !errors.As(err, &notFoundErr) {
+		t.Errorf("expected *NotFoundError; got %v\n", err)
+	}
+}
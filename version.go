@@ -0,0 +1,134 @@
+package fixr
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// versionMu guards FixrVersion and FixrPlatformVer, so that requests made while the
+// version is being updated never send a mixture of old and new values.
+var versionMu sync.RWMutex
+
+// SetFixrVersion sets FixrVersion to app and FixrPlatformVer to platform together, leaving
+// FixrPlatformVer unchanged if platform is empty. Unlike assigning the variables directly, it is
+// safe to call while requests are being made, which never send the new version of one with the
+// old version of the other.
+func SetFixrVersion(app, platform string) {
+	versionMu.Lock()
+	defer versionMu.Unlock()
+	FixrVersion = app
+	if len(platform) > 0 {
+		FixrPlatformVer = platform
+	}
+}
+
+// fixrVersions returns FixrVersion and FixrPlatformVer.
+func fixrVersions() (string, string) {
+	versionMu.RLock()
+	defer versionMu.RUnlock()
+	return FixrVersion, FixrPlatformVer
+}
+
+type versionResponse struct {
+	apiError
+	AppVersion      string `json:"app_version"`
+	MinAppVersion   string `json:"min_app_version"`
+	PlatformVersion string `json:"platform_version"`
+}
+
+// WithVersionChangeCallback sets a function called by AutoUpdateVersion with the old and new
+// FixrVersion whenever it changes.
+func WithVersionChangeCallback(f func(old, new string)) ClientOption {
+	return func(c *Client) error {
+		if f == nil {
+			return errors.New("version change callback cannot be nil")
+		}
+		c.onVersionChanged = f
+		return nil
+	}
+}
+
+func (c *Client) latestVersion(ctx context.Context) (*versionResponse, error) {
+	resp := versionResponse{}
+	if err := c.get(ctx, c.url(versionPath), false, &resp); err != nil {
+		return nil, errors.Wrap(err, "error getting latest version")
+	}
+	if len(resp.AppVersion) == 0 {
+		return nil, errors.New("error getting latest version: no version returned")
+	}
+	return &resp, nil
+}
+
+// DetectLatestAPIVersion returns the latest FIXR app version, as expected by the API.
+func (c *Client) DetectLatestAPIVersion(ctx context.Context) (string, error) {
+	resp, err := c.latestVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	return resp.AppVersion, nil
+}
+
+// AutoUpdateVersion sets FixrVersion (and FixrPlatformVer, if given by the API) to the latest
+// version (see DetectLatestAPIVersion), calling the client's version change callback (see
+// WithVersionChangeCallback) if FixrVersion changed. Like SetFixrVersion, it is safe to call
+// while requests are being made.
+func (c *Client) AutoUpdateVersion(ctx context.Context) error {
+	resp, err := c.latestVersion(ctx)
+	if err != nil {
+		return err
+	}
+	versionMu.Lock()
+	old := FixrVersion
+	FixrVersion = resp.AppVersion
+	if len(resp.PlatformVersion) > 0 {
+		FixrPlatformVer = resp.PlatformVersion
+	}
+	versionMu.Unlock()
+	if old != resp.AppVersion && c.onVersionChanged != nil {
+		c.onVersionChanged(old, resp.AppVersion)
+	}
+	return nil
+}
+
+// CheckAPIVersion checks that FixrVersion is still supported by the FIXR API.
+// An *APIVersionError will be returned if it is not.
+func (c *Client) CheckAPIVersion(ctx context.Context) error {
+	resp := versionResponse{}
+	if err := c.get(ctx, c.url(versionPath), false, &resp); err != nil {
+		if versionErr := new(APIVersionError); errors.As(err, &versionErr) {
+			return versionErr
+		}
+		return errors.Wrap(err, "error checking version")
+	}
+	actual, _ := fixrVersions()
+	if len(resp.MinAppVersion) > 0 && compareVersions(actual, resp.MinAppVersion) < 0 {
+		return &APIVersionError{Required: resp.MinAppVersion, Actual: actual}
+	}
+	return nil
+}
+
+// compareVersions compares two dotted version numbers (e.g. "1.34.0"), returning -1, 0 or 1
+// if a is older than, the same as or newer than b. Non-numeric parts compare as zero.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
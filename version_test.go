@@ -0,0 +1,116 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestCheckAPIVersion(t *testing.T) {
+	defer SetFixrVersion(FixrVersion, FixrPlatformVer)
+	SetFixrVersion("1.34.0", "")
+	c := newTestClient(t, serveFile(t, "testdata/version.json"))
+	if err := c.CheckAPIVersion(context.Background()); err != nil {
+		t.Errorf("expected no error; got %v\n", err)
+	}
+
+	c = newTestClient(t, serveFile(t, "testdata/version_outdated.json"))
+	versionErr := new(APIVersionError)
+	if err := c.CheckAPIVersion(context.Background()); !errors.As(err, &versionErr) {
+		t.Fatalf("expected *APIVersionError; got %v\n", err)
+	}
+	if versionErr.Required != "1.40.0" || versionErr.Actual != "1.34.0" {
+		t.Errorf("expected %s and %s; got %s and %s\n", "1.40.0", "1.34.0", versionErr.Required, versionErr.Actual)
+	}
+}
+
+func TestUpgradeRequired(t *testing.T) {
+	defer SetFixrVersion(FixrVersion, FixrPlatformVer)
+	SetFixrVersion("1.34.0", "")
+	data, err := os.ReadFile("testdata/upgrade_required.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, statusHandler(http.StatusUpgradeRequired, string(data)))
+	versionErr := new(APIVersionError)
+	if _, err := c.Event(1); !errors.As(err, &versionErr) {
+		t.Fatalf("expected *APIVersionError; got %v\n", err)
+	}
+	if versionErr.Required != "1.40.0" || versionErr.Actual != "1.34.0" {
+		t.Errorf("expected %s and %s; got %s and %s\n", "1.40.0", "1.34.0", versionErr.Required, versionErr.Actual)
+	}
+	if err := c.CheckAPIVersion(context.Background()); !errors.As(err, &versionErr) {
+		t.Errorf("expected *APIVersionError; got %v\n", err)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.34.0", "1.34.0", 0},
+		{"1.34.0", "1.34", 0},
+		{"1.9.0", "1.34.0", -1},
+		{"2.0", "1.34.0", 1},
+	} {
+		if result := compareVersions(test.a, test.b); result != test.expected {
+			t.Errorf("expected %d; got %d (%s vs %s)\n", test.expected, result, test.a, test.b)
+		}
+	}
+}
+
+func TestAutoUpdateVersion(t *testing.T) {
+	defer SetFixrVersion(fixrVersions())
+	SetFixrVersion("1.34.0", "")
+	var changes []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"app_version": "1.40.2", "platform_version": "Chrome/120.0.0.0"}`)
+	}, WithVersionChangeCallback(func(old, new string) {
+		changes = append(changes, old+" -> "+new)
+	}))
+	latest, err := c.DetectLatestAPIVersion(context.Background())
+	if err != nil || latest != "1.40.2" {
+		t.Errorf("expected %s; got %s (%v)\n", "1.40.2", latest, err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := c.AutoUpdateVersion(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if app, platform := fixrVersions(); app != "1.40.2" || platform != "Chrome/120.0.0.0" {
+		t.Errorf("expected %s and %s; got %s and %s\n", "1.40.2", "Chrome/120.0.0.0", app, platform)
+	}
+	if expected := "[1.34.0 -> 1.40.2]"; fmt.Sprint(changes) != expected {
+		t.Errorf("expected %s; got %v\n", expected, changes)
+	}
+
+	c = newTestClient(t, statusHandler(http.StatusOK, `{}`))
+	if _, err := c.DetectLatestAPIVersion(context.Background()); err == nil {
+		t.Error("expected a missing version to fail")
+	}
+}
+
+func TestSetFixrVersion(t *testing.T) {
+	defer SetFixrVersion(FixrVersion, FixrPlatformVer)
+	var app, platform string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		app, platform = r.Header.Get("FIXR-App-Version"), r.Header.Get("FIXR-Platform-Version")
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+	SetFixrVersion("2.0.0", "Chrome/120.0.0.0")
+	if _, err := c.Event(1); err != nil {
+		t.Fatal(err)
+	}
+	if app != "2.0.0" || platform != "Chrome/120.0.0.0" {
+		t.Errorf("expected %s and %s; got %s and %s\n", "2.0.0", "Chrome/120.0.0.0", app, platform)
+	}
+	SetFixrVersion("2.1.0", "")
+	if app, platform := fixrVersions(); app != "2.1.0" || platform != "Chrome/120.0.0.0" {
+		t.Errorf("expected %s and %s; got %s and %s\n", "2.1.0", "Chrome/120.0.0.0", app, platform)
+	}
+}
@@ -0,0 +1,66 @@
+package fixr
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// WaitlistEntry is the user's place on the waitlist for a sold-out ticket.
+type WaitlistEntry struct {
+	apiError
+	Position      int    `json:"position"`
+	EstimatedWait string `json:"estimated_wait"`
+	NotifyEmail   bool   `json:"notify_email"`
+}
+
+// waitlistError returns the corresponding typed error if err carries a waitlist error code.
+func waitlistError(err error) error {
+	switch errorCode(err) {
+	case codeOnWaitlist:
+		return &AlreadyOnWaitlistError{*asAPIError(err)}
+	case codeNotOnWaitlist:
+		return &NotOnWaitlistError{*asAPIError(err)}
+	}
+	return nil
+}
+
+// WaitlistJoin adds the user to the waitlist for the (sold-out) ticket with the given ID, so
+// that they are notified if it becomes available. An *AlreadyOnWaitlistError will be returned
+// if the user is already on the waitlist.
+func (c *Client) WaitlistJoin(ctx context.Context, ticketID int) (*WaitlistEntry, error) {
+	entry := WaitlistEntry{}
+	if err := c.post(ctx, c.url(waitlistPath, ticketID), new(bytes.Buffer), true, &entry); err != nil {
+		if waitlistErr := waitlistError(err); waitlistErr != nil {
+			return nil, waitlistErr
+		}
+		return nil, errors.Wrap(err, "error joining waitlist")
+	}
+	return &entry, nil
+}
+
+// WaitlistLeave removes the user from the waitlist for the ticket with the given ID.
+// A *NotOnWaitlistError will be returned if the user is not on the waitlist.
+func (c *Client) WaitlistLeave(ctx context.Context, ticketID int) error {
+	if err := c.del(ctx, c.url(waitlistPath, ticketID), true, new(apiError)); err != nil {
+		if waitlistErr := waitlistError(err); waitlistErr != nil {
+			return waitlistErr
+		}
+		return errors.Wrap(err, "error leaving waitlist")
+	}
+	return nil
+}
+
+// GetWaitlistPosition returns the user's place on the waitlist for the ticket with the given ID.
+// A *NotOnWaitlistError will be returned if the user is not on the waitlist.
+func (c *Client) GetWaitlistPosition(ctx context.Context, ticketID int) (*WaitlistEntry, error) {
+	entry := WaitlistEntry{}
+	if err := c.get(ctx, c.url(waitlistPath, ticketID), true, &entry); err != nil {
+		if waitlistErr := waitlistError(err); waitlistErr != nil {
+			return nil, waitlistErr
+		}
+		return nil, errors.Wrap(err, "error getting waitlist position")
+	}
+	return &entry, nil
+}
@@ -0,0 +1,55 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestWaitlist(t *testing.T) {
+	joined := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ticket/1/waitlist" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch {
+		case r.Method == "POST" && joined:
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message": "already on waitlist", "code": "already_on_waitlist"}`)
+		case r.Method != "POST" && !joined:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "not on waitlist", "code": "not_on_waitlist"}`)
+		case r.Method == "DELETE":
+			joined = false
+		default:
+			joined = true
+			fmt.Fprint(w, `{"position": 3, "estimated_wait": "2 days", "notify_email": true}`)
+		}
+	})
+	c.AuthToken = "abc"
+	entry, err := c.WaitlistJoin(context.Background(), 1)
+	if err != nil || entry.Position != 3 || entry.EstimatedWait != "2 days" || !entry.NotifyEmail {
+		t.Errorf("expected position %d; got %+v (%v)\n", 3, entry, err)
+	}
+	onWaitlistErr := new(AlreadyOnWaitlistError)
+	if _, err := c.WaitlistJoin(context.Background(), 1); !errors.As(err, &onWaitlistErr) {
+		t.Errorf("expected *AlreadyOnWaitlistError; got %v\n", err)
+	}
+	if entry, err := c.GetWaitlistPosition(context.Background(), 1); err != nil || entry.Position != 3 {
+		t.Errorf("expected position %d; got %+v (%v)\n", 3, entry, err)
+	}
+	if err := c.WaitlistLeave(context.Background(), 1); err != nil {
+		t.Error(err)
+	}
+	notOnWaitlistErr := new(NotOnWaitlistError)
+	if err := c.WaitlistLeave(context.Background(), 1); !errors.As(err, &notOnWaitlistErr) {
+		t.Errorf("expected *NotOnWaitlistError; got %v\n", err)
+	}
+	if _, err := c.GetWaitlistPosition(context.Background(), 1); !errors.As(err, &notOnWaitlistErr) {
+		t.Errorf("expected *NotOnWaitlistError; got %v\n", err)
+	}
+}
@@ -0,0 +1,154 @@
+package fixr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// poll calls fn immediately and then at every interval until ctx is done.
+func poll(ctx context.Context, interval time.Duration, fn func()) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fn()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func sendError(ctx context.Context, errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	case <-ctx.Done():
+	}
+}
+
+func ticketChanged(a, b *Ticket) bool {
+	return a.SoldOut != b.SoldOut || a.Expired != b.Expired || a.Invalid != b.Invalid || a.Price != b.Price
+}
+
+// WatchTicketAvailability polls the event with the given ID every interval (5s if not positive),
+// sending the ticket with ticketID when it is first fetched and whenever its availability
+// (SoldOut, Expired, Invalid) or Price changes. Errors are sent without stopping the watcher.
+// Both channels are closed once ctx is done; callers must receive from both until then.
+func (c *Client) WatchTicketAvailability(ctx context.Context, eventID, ticketID int, interval time.Duration) (<-chan Ticket, <-chan error) {
+	tickets, errs := make(chan Ticket), make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(tickets)
+		var last *Ticket
+		poll(ctx, interval, func() {
+			event, err := c.fetchEvent(ctx, eventID)
+			if err != nil {
+				sendError(ctx, errs, err)
+				return
+			}
+			ticket, ok := findTicket(event.Tickets, ticketID)
+			if !ok {
+				sendError(ctx, errs, errors.Errorf("ticket %d not found in event %d", ticketID, eventID))
+				return
+			}
+			if last != nil && !ticketChanged(last, ticket) {
+				return
+			}
+			last = ticket
+			select {
+			case tickets <- *ticket:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return tickets, errs
+}
+
+// WatchEvent polls the event with the given ID every interval (5s if not positive), sending the
+// event when it is first fetched and whenever any of its fields change. Errors are sent without
+// stopping the watcher. Both channels are closed once ctx is done; callers must receive from both until then.
+func (c *Client) WatchEvent(ctx context.Context, id int, interval time.Duration) (<-chan Event, <-chan error) {
+	events, errs := make(chan Event), make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(events)
+		var last *Event
+		poll(ctx, interval, func() {
+			event, err := c.fetchEvent(ctx, id)
+			if err != nil {
+				sendError(ctx, errs, err)
+				return
+			}
+			if last != nil && reflect.DeepEqual(last, event) {
+				return
+			}
+			last = event
+			select {
+			case events <- *copyEvent(event):
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events, errs
+}
+
+// StreamError is sent by StreamEvents when searching for events fails.
+type StreamError struct {
+	Err error
+}
+
+func (e *StreamError) Error() string {
+	return "error streaming events: " + e.Err.Error()
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// StreamEvents searches for the events matching filter (see SearchEvents) every interval (5s
+// if not positive), sending each event when it is first found and whenever it changes. Errors
+// are sent as a *StreamError without stopping the stream. Both channels are closed once ctx
+// is done; callers must receive from both until then.
+func (c *Client) StreamEvents(ctx context.Context, filter EventFilter, interval time.Duration) (<-chan Event, <-chan error) {
+	events, errs := make(chan Event), make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(events)
+		seen := make(map[int][sha256.Size]byte)
+		poll(ctx, interval, func() {
+			list, err := c.SearchEventsWithContext(ctx, "", filter)
+			if err != nil {
+				sendError(ctx, errs, &StreamError{Err: err})
+				return
+			}
+			for _, event := range list.Items {
+				data, err := json.Marshal(event)
+				if err != nil {
+					sendError(ctx, errs, &StreamError{Err: err})
+					continue
+				}
+				hash := sha256.Sum256(data)
+				if last, ok := seen[event.ID]; ok && last == hash {
+					continue
+				}
+				seen[event.ID] = hash
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}()
+	return events, errs
+}
@@ -0,0 +1,87 @@
+package fixr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestWatchTicketAvailability(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The ticket is released on the third poll; other polls are unchanged
+		invalid := atomic.AddInt32(&calls, 1) < 3
+		fmt.Fprintf(w, `{"id": 1, "tickets": [{"id": 2, "not_yet_valid": %t}]}`, invalid)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tickets, errs := c.WatchTicketAvailability(ctx, 1, 2, time.Millisecond)
+	var received []Ticket
+	for len(received) < 2 {
+		select {
+		case ticket := <-tickets:
+			received = append(received, ticket)
+		case err := <-errs:
+			t.Fatal(err)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after receiving %v\n", received)
+		}
+	}
+	if !received[0].Invalid || received[1].Invalid {
+		t.Errorf("expected ticket to become valid; got %v\n", received)
+	}
+	cancel()
+	for range tickets {
+	}
+	for range errs {
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			fmt.Fprint(w, `{"count": 1, "results": [{"id": 1, "name": "a"}]}`)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		case 3:
+			fmt.Fprint(w, `{"count": 2, "results": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`)
+		default:
+			fmt.Fprint(w, `{"count": 2, "results": [{"id": 1, "name": "c"}, {"id": 2, "name": "b"}]}`)
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, errs := c.StreamEvents(ctx, EventFilter{City: "London"}, time.Millisecond)
+	var received []string
+	var streamErr *StreamError
+	for len(received) < 3 {
+		select {
+		case event := <-events:
+			received = append(received, fmt.Sprintf("%d:%s", event.ID, event.Name))
+		case err := <-errs:
+			if !errors.As(err, &streamErr) {
+				t.Fatalf("expected *StreamError; got %v\n", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after receiving %v\n", received)
+		}
+	}
+	if expected := "[1:a 2:b 1:c]"; fmt.Sprint(received) != expected {
+		t.Errorf("expected %s; got %v\n", expected, received)
+	}
+	if streamErr == nil {
+		t.Error("expected a *StreamError")
+	}
+	cancel()
+	for range events {
+	}
+	for range errs {
+	}
+}